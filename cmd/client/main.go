@@ -81,6 +81,14 @@ var (
 						Name:  "exchange-rate",
 						Usage: "desired exchange rate of XMR:ETH, eg. --exchange-rate=0.1 means 10XMR = 1ETH",
 					},
+					&cli.StringFlag{
+						Name:  "payout-address",
+						Usage: "execute the swap on behalf of a customer, sending the ETH received to this address",
+					},
+					&cli.StringFlag{
+						Name:  "label",
+						Usage: "label identifying the customer the swap is executed for",
+					},
 					&cli.BoolFlag{
 						Name:  "subscribe",
 						Usage: "subscribe to push notifications about the swap's status",
@@ -106,6 +114,14 @@ var (
 						Name:  "provides-amount",
 						Usage: "amount of coin to send in the swap",
 					},
+					&cli.StringFlag{
+						Name:  "payout-address",
+						Usage: "execute the swap on behalf of a customer, sending the XMR received to this address",
+					},
+					&cli.StringFlag{
+						Name:  "label",
+						Usage: "label identifying the customer the swap is executed for",
+					},
 					&cli.BoolFlag{
 						Name:  "subscribe",
 						Usage: "subscribe to push notifications about the swap's status",
@@ -121,9 +137,15 @@ var (
 			},
 			{
 				Name:   "get-ongoing-swap",
-				Usage:  "get information about ongoing swap, if there is one",
+				Usage:  "get information about an ongoing swap",
 				Action: runGetOngoingSwap,
-				Flags:  []cli.Flag{daemonAddrFlag},
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of the ongoing swap; may be omitted if there is only one",
+					},
+					daemonAddrFlag,
+				},
 			},
 			{
 				Name:   "get-past-swap",
@@ -145,15 +167,27 @@ var (
 			},
 			{
 				Name:   "cancel",
-				Usage:  "cancel the ongoing swap if possible.",
+				Usage:  "cancel an ongoing swap if possible.",
 				Action: runCancel,
-				Flags:  []cli.Flag{daemonAddrFlag},
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of the ongoing swap; may be omitted if there is only one",
+					},
+					daemonAddrFlag,
+				},
 			},
 			{
 				Name:   "get-stage",
-				Usage:  "get the stage of the current swap.",
+				Usage:  "get the stage of an ongoing swap.",
 				Action: runGetStage,
-				Flags:  []cli.Flag{daemonAddrFlag},
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of the ongoing swap; may be omitted if there is only one",
+					},
+					daemonAddrFlag,
+				},
 			},
 			{
 				Name:   "set-swap-timeout",
@@ -167,6 +201,27 @@ var (
 					daemonAddrFlag,
 				},
 			},
+			{
+				Name: "prune",
+				Usage: "remove the infofiles of swaps which completed successfully longer ago than the retention period. " +
+					"infofiles of swaps which may still need to be recovered are never pruned.",
+				Action: runPrune,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "days",
+						Usage: "retention period, in days; defaults to the daemon's configured retention period",
+					},
+					&cli.BoolFlag{
+						Name:  "archive",
+						Usage: "move the infofiles to the archive directory with their key material removed, instead of deleting them", //nolint:lll
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "list the infofiles which would be pruned, without pruning them",
+					},
+					daemonAddrFlag,
+				},
+			},
 		},
 		Flags: []cli.Flag{daemonAddrFlag},
 	}
@@ -269,6 +324,8 @@ func runMake(ctx *cli.Context) error {
 		return errNoExchangeRate
 	}
 
+	payout := payoutFromFlags(ctx)
+
 	endpoint := ctx.String("daemon-addr")
 	if endpoint == "" {
 		endpoint = defaultSwapdAddress
@@ -280,7 +337,7 @@ func runMake(ctx *cli.Context) error {
 			return err
 		}
 
-		id, takenCh, statusCh, err := c.MakeOfferAndSubscribe(min, max, types.ExchangeRate(exchangeRate))
+		id, takenCh, statusCh, err := c.MakeOfferAndSubscribe(min, max, types.ExchangeRate(exchangeRate), payout)
 		if err != nil {
 			return err
 		}
@@ -301,7 +358,7 @@ func runMake(ctx *cli.Context) error {
 	}
 
 	c := rpcclient.NewClient(endpoint)
-	id, err := c.MakeOffer(min, max, exchangeRate)
+	id, err := c.MakeOffer(min, max, exchangeRate, payout)
 	if err != nil {
 		return err
 	}
@@ -310,6 +367,20 @@ func runMake(ctx *cli.Context) error {
 	return nil
 }
 
+// payoutFromFlags returns the payout given with --payout-address and --label, or nil if neither was set.
+func payoutFromFlags(ctx *cli.Context) *types.Payout {
+	address := ctx.String("payout-address")
+	label := ctx.String("label")
+	if address == "" && label == "" {
+		return nil
+	}
+
+	return &types.Payout{
+		Label:   label,
+		Address: address,
+	}
+}
+
 func runTake(ctx *cli.Context) error {
 	maddr := ctx.String("multiaddr")
 	if maddr == "" {
@@ -326,6 +397,8 @@ func runTake(ctx *cli.Context) error {
 		return errNoProvidesAmount
 	}
 
+	payout := payoutFromFlags(ctx)
+
 	endpoint := ctx.String("daemon-addr")
 	if endpoint == "" {
 		endpoint = defaultSwapdAddress
@@ -337,7 +410,7 @@ func runTake(ctx *cli.Context) error {
 			return err
 		}
 
-		id, statusCh, err := c.TakeOfferAndSubscribe(maddr, offerID, providesAmount)
+		id, statusCh, err := c.TakeOfferAndSubscribe(maddr, offerID, providesAmount, payout)
 		if err != nil {
 			return err
 		}
@@ -355,7 +428,7 @@ func runTake(ctx *cli.Context) error {
 	}
 
 	c := rpcclient.NewClient(endpoint)
-	id, err := c.TakeOffer(maddr, offerID, providesAmount)
+	id, err := c.TakeOffer(maddr, offerID, providesAmount, payout)
 	if err != nil {
 		return err
 	}
@@ -387,7 +460,7 @@ func runGetOngoingSwap(ctx *cli.Context) error {
 	}
 
 	c := rpcclient.NewClient(endpoint)
-	info, err := c.GetOngoingSwap()
+	info, err := c.GetOngoingSwap(ongoingSwapID(ctx))
 	if err != nil {
 		return err
 	}
@@ -403,6 +476,16 @@ func runGetOngoingSwap(ctx *cli.Context) error {
 	return nil
 }
 
+// ongoingSwapID returns the swap ID passed with --id, or nil if it wasn't set.
+func ongoingSwapID(ctx *cli.Context) *uint64 {
+	if !ctx.IsSet("id") {
+		return nil
+	}
+
+	id := uint64(ctx.Uint("id"))
+	return &id
+}
+
 func runGetPastSwap(ctx *cli.Context) error {
 	id := ctx.Uint("id")
 
@@ -451,7 +534,7 @@ func runCancel(ctx *cli.Context) error {
 	}
 
 	c := rpcclient.NewClient(endpoint)
-	resp, err := c.Cancel()
+	resp, err := c.Cancel(ongoingSwapID(ctx))
 	if err != nil {
 		return err
 	}
@@ -467,7 +550,7 @@ func runGetStage(ctx *cli.Context) error {
 	}
 
 	c := rpcclient.NewClient(endpoint)
-	resp, err := c.GetStage()
+	resp, err := c.GetStage(ongoingSwapID(ctx))
 	if err != nil {
 		return err
 	}
@@ -493,3 +576,33 @@ func runSetSwapTimeout(ctx *cli.Context) error {
 	fmt.Printf("Set timeout duration to %ds", duration)
 	return nil
 }
+
+func runPrune(ctx *cli.Context) error {
+	var retentionDays *uint64
+	if ctx.IsSet("days") {
+		days := uint64(ctx.Uint("days"))
+		retentionDays = &days
+	}
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := rpcclient.NewClient(endpoint)
+	pruned, err := c.Prune(retentionDays, ctx.Bool("archive"), ctx.Bool("dry-run"))
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("dry-run") {
+		fmt.Printf("Would prune %d infofiles:\n", len(pruned))
+	} else {
+		fmt.Printf("Pruned %d infofiles:\n", len(pruned))
+	}
+
+	for _, file := range pruned {
+		fmt.Println(file)
+	}
+	return nil
+}
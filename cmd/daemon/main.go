@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"os"
 	"strings"
+	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/noot/atomic-swap/cmd/utils"
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/hooks"
 	"github.com/noot/atomic-swap/net"
 	"github.com/noot/atomic-swap/protocol/alice"
 	"github.com/noot/atomic-swap/protocol/bob"
@@ -43,6 +46,8 @@ const (
 	defaultWSPort      = 6005
 	defaultAliceWSPort = 8081
 	defaultBobWSPort   = 8082
+
+	defaultRetentionDays = 30
 )
 
 var (
@@ -73,6 +78,8 @@ const (
 	flagDevBob       = "dev-bob"
 	flagDeploy       = "deploy"
 	flagTransferBack = "transfer-back"
+	flagHookScript   = "hook-script"
+	flagRetention    = "retention-days"
 
 	flagLog = "log"
 )
@@ -167,6 +174,15 @@ var (
 				Name:  flagTransferBack,
 				Usage: "when receiving XMR in a swap, transfer it back to the original wallet.",
 			},
+			&cli.StringFlag{
+				Name:  flagHookScript,
+				Usage: "path to a Starlark script whose functions are called on swap events",
+			},
+			&cli.UintFlag{
+				Name:  flagRetention,
+				Usage: "number of days to keep the infofiles of successfully completed swaps before they can be pruned",
+				Value: defaultRetentionDays,
+			},
 			&cli.StringFlag{
 				Name:  flagLog,
 				Usage: "set log level: one of [error|warn|info|debug]",
@@ -185,12 +201,14 @@ func main() {
 type aliceHandler interface {
 	rpc.Alice
 	SetMessageSender(net.MessageSender)
+	ResumeSwaps() error
 }
 
 type bobHandler interface {
 	net.Handler
 	rpc.Bob
 	SetMessageSender(net.MessageSender)
+	ResumeSwaps() error
 }
 
 type daemon struct {
@@ -221,6 +239,7 @@ func setLogLevels(c *cli.Context) error {
 	_ = logging.SetLogLevel("bob", level)
 	_ = logging.SetLogLevel("common", level)
 	_ = logging.SetLogLevel("cmd", level)
+	_ = logging.SetLogLevel("hooks", level)
 	_ = logging.SetLogLevel("net", level)
 	_ = logging.SetLogLevel("rpc", level)
 	return nil
@@ -328,6 +347,15 @@ func (d *daemon) make(c *cli.Context) error {
 		return err
 	}
 
+	// resume any swaps which were ongoing when we last exited
+	if err = a.ResumeSwaps(); err != nil {
+		return fmt.Errorf("failed to resume alice swaps: %w", err)
+	}
+
+	if err = b.ResumeSwaps(); err != nil {
+		return fmt.Errorf("failed to resume bob swaps: %w", err)
+	}
+
 	p = uint16(c.Uint(flagRPCPort))
 	switch {
 	case p != 0:
@@ -359,6 +387,8 @@ func (d *daemon) make(c *cli.Context) error {
 		Alice:       a,
 		Bob:         b,
 		SwapManager: sm,
+		Basepath:    cfg.Basepath,
+		Retention:   time.Duration(c.Uint(flagRetention)) * 24 * time.Hour,
 	}
 
 	s, err := rpc.NewServer(rpcCfg)
@@ -450,6 +480,14 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 		}
 	}
 
+	var hookEngine *hooks.Engine
+	if c.String(flagHookScript) != "" {
+		hookEngine, err = hooks.NewEngine(c.String(flagHookScript))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	walletFile := c.String("wallet-file")
 
 	// empty password is ok
@@ -471,6 +509,7 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 		SwapContract:         contract,
 		SwapContractAddress:  contractAddr,
 		TransferBack:         c.Bool(flagTransferBack),
+		Hooks:                hookEngine,
 	}
 
 	a, err = alice.NewInstance(aliceCfg)
@@ -492,6 +531,7 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 		GasPrice:             gasPrice,
 		GasLimit:             uint64(c.Uint(flagGasLimit)),
 		SwapManager:          sm,
+		Hooks:                hookEngine,
 	}
 
 	b, err = bob.NewInstance(bobCfg)
@@ -321,7 +321,7 @@ func (d *daemon) takeOffer(done <-chan struct{}) {
 	log.Infof("node %d taking offer %s", d.idx, offer.GetID().String())
 
 	_, takerStatusCh, err := wsc.TakeOfferAndSubscribe(peer,
-		offer.GetID().String(), providesAmount)
+		offer.GetID().String(), providesAmount, nil)
 	if err != nil {
 		d.errCh <- err
 		return
@@ -373,6 +373,7 @@ func (d *daemon) makeOffer(done <-chan struct{}) {
 	offerID, takenCh, statusCh, err := wsc.MakeOfferAndSubscribe(minProvidesAmount,
 		maxProvidesAmount,
 		getRandomExchangeRate(),
+		nil,
 	)
 	if err != nil {
 		log.Errorf("failed to make offer (node %d): %s", d.idx, err)
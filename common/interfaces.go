@@ -14,6 +14,7 @@ type SwapState interface {
 // It is implemented by *alice.swapState and *bob.swapState
 type SwapStateNet interface {
 	HandleProtocolMessage(msg message.Message) (resp message.Message, done bool, err error)
+	ID() uint64
 	Exit() error
 }
 
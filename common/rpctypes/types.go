@@ -41,6 +41,14 @@ type TakeOfferRequest struct {
 	Multiaddr      string  `json:"multiaddr"`
 	OfferID        string  `json:"offerID"`
 	ProvidesAmount float64 `json:"providesAmount"`
+
+	// if set, the swap is executed on behalf of a customer, and the monero received is sent to
+	// PayoutAddress. Label identifies the customer in the swap's info.
+	PayoutAddress string `json:"payoutAddress,omitempty"`
+	Label         string `json:"label,omitempty"`
+
+	// if set, the swap's result is POSTed to CallbackURL once it completes.
+	CallbackURL string `json:"callbackURL,omitempty"`
 }
 
 // TakeOfferResponse ...
@@ -54,6 +62,14 @@ type MakeOfferRequest struct {
 	MinimumAmount float64            `json:"minimumAmount"`
 	MaximumAmount float64            `json:"maximumAmount"`
 	ExchangeRate  types.ExchangeRate `json:"exchangeRate"`
+
+	// if set, swaps of this offer are executed on behalf of a customer, and the ether received is
+	// sent to PayoutAddress. Label identifies the customer in the swap's info.
+	PayoutAddress string `json:"payoutAddress,omitempty"`
+	Label         string `json:"label,omitempty"`
+
+	// if set, the result of each swap of this offer is POSTed to CallbackURL once it completes.
+	CallbackURL string `json:"callbackURL,omitempty"`
 }
 
 // MakeOfferResponse ...
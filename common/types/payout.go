@@ -0,0 +1,12 @@
+package types
+
+// Payout directs the funds received in a swap to a customer, for swaps which the daemon executes
+// on another party's behalf. The swap itself is still executed with the daemon's own keys; once the
+// funds are received, they're forwarded to the payout address.
+type Payout struct {
+	// Label identifies the customer the swap is executed for.
+	Label string `json:"label,omitempty"`
+	// Address is an ethereum address if the coin received in the swap is ETH, or a monero address
+	// if it's XMR. If it's empty, the funds are kept by the daemon.
+	Address string `json:"address,omitempty"`
+}
@@ -6,10 +6,10 @@ import (
 	"errors"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 
 	logging "github.com/ipfs/go-log"
 )
@@ -35,7 +35,8 @@ func Reverse(s []byte) []byte {
 }
 
 // WaitForReceipt waits for the receipt for the given transaction to be available and returns it.
-func WaitForReceipt(ctx context.Context, ethclient *ethclient.Client, txHash ethcommon.Hash) (*ethtypes.Receipt, error) { //nolint:lll
+// The backend is usually an *ethclient.Client, but may also be a simulated backend in tests.
+func WaitForReceipt(ctx context.Context, ethclient bind.DeployBackend, txHash ethcommon.Hash) (*ethtypes.Receipt, error) { //nolint:lll
 	for i := 0; i < maxRetries; i++ {
 		receipt, err := ethclient.TransactionReceipt(ctx, txHash)
 		if err != nil {
@@ -1,6 +1,10 @@
 package mcrypto
 
 import (
+	"bytes"
+	"errors"
+	"strings"
+
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/crypto"
 )
@@ -8,11 +12,55 @@ import (
 const (
 	addressPrefixMainnet  byte = 18
 	addressPrefixStagenet byte = 24
+
+	subaddressPrefixMainnet  byte = 42
+	subaddressPrefixStagenet byte = 36
+
+	// network prefix + public spend key + public view key + checksum
+	addressLength = 1 + 32 + 32 + 4
+)
+
+var (
+	errInvalidAddressLength   = errors.New("invalid monero address length")
+	errInvalidAddressChars    = errors.New("monero address contains invalid characters")
+	errInvalidAddressChecksum = errors.New("invalid monero address checksum")
+	errInvalidAddressNetwork  = errors.New("monero address is for a different network")
 )
 
 // Address represents a base58-encoded string
 type Address string
 
+// ValidateEnv checks that the address is a well-formed standard address or subaddress
+// for the given environment.
+func (a Address) ValidateEnv(env common.Environment) error {
+	if strings.Trim(string(a), BASE58) != "" {
+		return errInvalidAddressChars
+	}
+
+	b := DecodeMoneroBase58(string(a))
+	if len(b) != addressLength {
+		return errInvalidAddressLength
+	}
+
+	checksum := getChecksum(b[:addressLength-4])
+	if !bytes.Equal(checksum[:], b[addressLength-4:]) {
+		return errInvalidAddressChecksum
+	}
+
+	switch env {
+	case common.Mainnet, common.Development:
+		if b[0] != addressPrefixMainnet && b[0] != subaddressPrefixMainnet {
+			return errInvalidAddressNetwork
+		}
+	case common.Stagenet:
+		if b[0] != addressPrefixStagenet && b[0] != subaddressPrefixStagenet {
+			return errInvalidAddressNetwork
+		}
+	}
+
+	return nil
+}
+
 func getChecksum(data ...[]byte) (result [4]byte) {
 	keccak256 := crypto.Keccak256(data...)
 	copy(result[:], keccak256[:4])
@@ -22,6 +22,9 @@ var base58Lookup = map[string]int{
 }
 var bigBase = big.NewInt(58)
 
+// decodedChunkSizes maps the length of an encoded chunk to the length of the decoded chunk
+var decodedChunkSizes = map[int]int{2: 1, 3: 2, 5: 3, 6: 4, 7: 5, 9: 6, 10: 7, 11: 8}
+
 func encodeChunk(raw []byte, padding int) (result string) {
 	remainder := new(big.Int)
 	remainder.SetBytes(raw)
@@ -48,6 +51,11 @@ func decodeChunk(encoded string) (result []byte) {
 		currentMultiplier.Mul(currentMultiplier, bigBase)
 	}
 	result = bigResult.Bytes()
+
+	// restore any leading zero bytes lost in the conversion
+	if size, has := decodedChunkSizes[len(encoded)]; has && len(result) < size {
+		result = append(make([]byte, size-len(result)), result...)
+	}
 	return
 }
 
@@ -48,6 +48,22 @@ func TestPrivateKeyPairToAddress(t *testing.T) {
 	require.Equal(t, pvkBytes, kp.vk.Public().Hex())
 }
 
+func TestAddress_ValidateEnv(t *testing.T) {
+	address := Address("49oFJna6jrkJYvmupQktXKXmhnktf1aCvUmwp8HJGvY7fdXpLMTVeqmZLWQLkyHXuU9Z8mZ78LordCmp3Nqx5T9GFdEGueB")
+	require.NoError(t, address.ValidateEnv(common.Mainnet))
+	require.Equal(t, errInvalidAddressNetwork, address.ValidateEnv(common.Stagenet))
+	require.Equal(t, errInvalidAddressLength, address[:90].ValidateEnv(common.Mainnet))
+	require.Equal(t, errInvalidAddressChars, (address[:94] + "0").ValidateEnv(common.Mainnet))
+	require.Equal(t, errInvalidAddressChecksum, (address[:94] + "C").ValidateEnv(common.Mainnet))
+
+	// addresses with leading zero bytes in a chunk must round-trip
+	for i := 0; i < 64; i++ {
+		kp, err := GenerateKeys()
+		require.NoError(t, err)
+		require.NoError(t, kp.Address(common.Stagenet).ValidateEnv(common.Stagenet))
+	}
+}
+
 func TestGeneratePrivateKeyPair(t *testing.T) {
 	_, err := GenerateKeys()
 	require.NoError(t, err)
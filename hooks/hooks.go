@@ -0,0 +1,182 @@
+// Package hooks implements an optional embedded Starlark scripting engine, which allows
+// operators to react to swap events (adjust offer rates, veto counterparties, log
+// hedging signals) without recompiling the daemon.
+//
+// Scripts are sandboxed: they have no filesystem or network access, `load` is disabled,
+// and every call is bounded by a maximum number of execution steps. Module-level values are
+// frozen once the script has loaded, so handlers cannot keep mutable state between calls.
+//
+// A script may define any of the following functions:
+//
+//	def on_make(offer):                   # return a new exchange rate, or None to keep it
+//	def on_take(offer, provided_amount):  # return False or a string reason to veto the take
+//	def on_status(swap_id, status):       # called whenever a swap's status changes
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/noot/atomic-swap/common/types"
+
+	logging "github.com/ipfs/go-log"
+	"go.starlark.net/starlark"
+)
+
+const (
+	onMake   = "on_make"
+	onTake   = "on_take"
+	onStatus = "on_status"
+
+	maxExecutionSteps = 1 << 20
+)
+
+var (
+	log = logging.Logger("hooks")
+
+	errVetoed = errors.New("swap vetoed by hook script")
+)
+
+// Engine runs an operator-provided Starlark script in response to swap events.
+// A nil *Engine is valid and does nothing.
+type Engine struct {
+	mu      sync.Mutex
+	path    string
+	globals starlark.StringDict
+}
+
+// NewEngine loads and executes the script at the given path, returning an *Engine
+// which can be used to invoke the script's event handlers.
+func NewEngine(path string) (*Engine, error) {
+	src, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hook script: %w", err)
+	}
+
+	e := &Engine{
+		path: path,
+	}
+
+	globals, err := starlark.ExecFile(e.newThread(), path, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hook script: %w", err)
+	}
+
+	for _, name := range []string{onMake, onTake, onStatus} {
+		fn, has := globals[name]
+		if !has {
+			continue
+		}
+
+		if _, ok := fn.(starlark.Callable); !ok {
+			return nil, fmt.Errorf("%s in hook script is not a function", name)
+		}
+	}
+
+	e.globals = globals
+	log.Infof("loaded hook script %s", path)
+	return e, nil
+}
+
+// newThread returns a sandboxed thread; load() is unavailable as Load is nil.
+func (e *Engine) newThread() *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: e.path,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Infof("%s: %s", e.path, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxExecutionSteps)
+	return thread
+}
+
+func (e *Engine) call(name string, args ...starlark.Value) (starlark.Value, bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	fn, has := e.globals[name]
+	if !has {
+		return nil, false, nil
+	}
+
+	res, err := starlark.Call(e.newThread(), fn, args, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("hook %s failed: %w", name, err)
+	}
+
+	return res, true, nil
+}
+
+// OnMake is called when a new offer is made. If the script returns a number, it is used as
+// the offer's new exchange rate.
+func (e *Engine) OnMake(o *types.Offer) error {
+	if e == nil {
+		return nil
+	}
+
+	res, called, err := e.call(onMake, offerToDict(o))
+	if err != nil || !called || res == starlark.None {
+		return err
+	}
+
+	rate, ok := starlark.AsFloat(res)
+	if !ok || rate <= 0 {
+		return fmt.Errorf("%s must return a positive exchange rate or None, got %s", onMake, res)
+	}
+
+	log.Infof("%s adjusted exchange rate of offer from %v to %v", onMake, o.ExchangeRate, rate)
+	o.ExchangeRate = types.ExchangeRate(rate)
+	return nil
+}
+
+// OnTake is called when a taker attempts to take one of our offers, before any keys are
+// exchanged. It returns an error if the script vetoes the take.
+func (e *Engine) OnTake(o *types.Offer, providedAmount float64) error {
+	if e == nil {
+		return nil
+	}
+
+	res, called, err := e.call(onTake, offerToDict(o), starlark.Float(providedAmount))
+	if err != nil || !called {
+		return err
+	}
+
+	switch res := res.(type) {
+	case starlark.NoneType:
+		return nil
+	case starlark.Bool:
+		if !res {
+			return errVetoed
+		}
+		return nil
+	case starlark.String:
+		return fmt.Errorf("%w: %s", errVetoed, string(res))
+	default:
+		return fmt.Errorf("%s must return a bool, string or None, got %s", onTake, res.Type())
+	}
+}
+
+// OnStatus is called whenever a swap's status is updated. Errors are logged, as a failing
+// script must not interrupt an ongoing swap.
+func (e *Engine) OnStatus(id uint64, status types.Status) {
+	if e == nil {
+		return
+	}
+
+	if _, _, err := e.call(onStatus, starlark.MakeUint64(id), starlark.String(status.String())); err != nil {
+		log.Warnf("%s", err)
+	}
+}
+
+func offerToDict(o *types.Offer) *starlark.Dict {
+	d := starlark.NewDict(5)
+	_ = d.SetKey(starlark.String("id"), starlark.String(o.GetID().String()))
+	_ = d.SetKey(starlark.String("provides"), starlark.String(o.Provides))
+	_ = d.SetKey(starlark.String("min_amount"), starlark.Float(o.MinimumAmount))
+	_ = d.SetKey(starlark.String("max_amount"), starlark.Float(o.MaximumAmount))
+	_ = d.SetKey(starlark.String("exchange_rate"), starlark.Float(o.ExchangeRate))
+	return d
+}
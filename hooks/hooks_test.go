@@ -0,0 +1,101 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/noot/atomic-swap/common/types"
+
+	"github.com/stretchr/testify/require"
+	"go.starlark.net/starlark"
+)
+
+const testScript = `
+def on_make(offer):
+    if offer["exchange_rate"] < 0.05:
+        return 0.05
+    return None
+
+def on_take(offer, provided_amount):
+    if provided_amount > 10:
+        return "too large"
+    return provided_amount >= offer["min_amount"]
+
+def on_status(swap_id, status):
+    return "%d:%s" % (swap_id, status)
+`
+
+func newTestEngine(t *testing.T, script string) *Engine {
+	path := filepath.Join(t.TempDir(), "hooks.star")
+	err := os.WriteFile(path, []byte(script), 0600)
+	require.NoError(t, err)
+
+	e, err := NewEngine(path)
+	require.NoError(t, err)
+	return e
+}
+
+func TestEngine_OnMake(t *testing.T) {
+	e := newTestEngine(t, testScript)
+
+	o := &types.Offer{
+		Provides:      types.ProvidesXMR,
+		MinimumAmount: 1,
+		MaximumAmount: 2,
+		ExchangeRate:  0.01,
+	}
+	err := e.OnMake(o)
+	require.NoError(t, err)
+	require.Equal(t, types.ExchangeRate(0.05), o.ExchangeRate)
+
+	o.ExchangeRate = 0.1
+	err = e.OnMake(o)
+	require.NoError(t, err)
+	require.Equal(t, types.ExchangeRate(0.1), o.ExchangeRate)
+}
+
+func TestEngine_OnTake(t *testing.T) {
+	e := newTestEngine(t, testScript)
+
+	o := &types.Offer{
+		MinimumAmount: 1,
+		MaximumAmount: 20,
+	}
+
+	require.NoError(t, e.OnTake(o, 2))
+	require.True(t, errors.Is(e.OnTake(o, 0.5), errVetoed))
+	err := e.OnTake(o, 11)
+	require.True(t, errors.Is(err, errVetoed))
+	require.Contains(t, err.Error(), "too large")
+}
+
+func TestEngine_OnStatus(t *testing.T) {
+	e := newTestEngine(t, testScript)
+	e.OnStatus(1, types.ExpectingKeys)
+
+	res, called, err := e.call(onStatus, starlark.MakeUint64(1), starlark.String(types.CompletedSuccess.String()))
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Equal(t, starlark.String("1:Success"), res)
+}
+
+func TestEngine_Nil(t *testing.T) {
+	var e *Engine
+	require.NoError(t, e.OnMake(&types.Offer{}))
+	require.NoError(t, e.OnTake(&types.Offer{}, 1))
+	e.OnStatus(0, types.CompletedAbort)
+}
+
+func TestEngine_Sandboxed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.star")
+	err := os.WriteFile(path, []byte(`load("other.star", "x")`), 0600)
+	require.NoError(t, err)
+
+	_, err = NewEngine(path)
+	require.Error(t, err)
+
+	e := newTestEngine(t, "def on_take(offer, amount):\n    for i in range(1 << 30):\n        pass\n")
+	require.Error(t, e.OnTake(&types.Offer{}, 1))
+}
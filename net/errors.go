@@ -7,7 +7,7 @@ import (
 var (
 	errNilStream             = errors.New("stream is nil")
 	errFailedToBootstrap     = errors.New("failed to bootstrap to any bootnode")
-	errNoOngoingSwap         = errors.New("no swap currently happening")
-	errSwapAlreadyInProgress = errors.New("already have ongoing swap")
+	errNoOngoingSwap         = errors.New("no swap with given ID currently happening")
+	errSwapAlreadyInProgress = errors.New("already have ongoing swap with given ID")
 	errInvalidBufferLength   = errors.New("buffer has length 0")
 )
@@ -50,10 +50,9 @@ type host struct {
 	discovery *discovery
 	handler   Handler
 
-	// swap instance info
-	swapMu     sync.Mutex
-	swapState  SwapState
-	swapStream libp2pnetwork.Stream
+	// ongoing swaps, keyed by swap ID
+	swapMu sync.Mutex
+	swaps  map[uint64]*swap
 
 	queryMu  sync.Mutex
 	queryBuf []byte
@@ -149,6 +148,7 @@ func NewHost(cfg *Config) (*host, error) { //nolint:revive
 		h:          h,
 		handler:    cfg.Handler,
 		bootnodes:  bns,
+		swaps:      make(map[uint64]*swap),
 		queryBuf:   make([]byte, 2048),
 	}
 
@@ -224,16 +224,17 @@ func (h *host) Discover(provides types.ProvidesCoin, searchTime time.Duration) (
 	return h.discovery.discover(provides, searchTime)
 }
 
-// SendSwapMessage sends a message to the peer who we're currently doing a swap with.
-func (h *host) SendSwapMessage(msg Message) error {
+// SendSwapMessage sends a message to the peer who we're doing the swap with the given ID with.
+func (h *host) SendSwapMessage(msg Message, id uint64) error {
 	h.swapMu.Lock()
 	defer h.swapMu.Unlock()
 
-	if h.swapStream == nil {
+	swap, has := h.swaps[id]
+	if !has {
 		return errNoOngoingSwap
 	}
 
-	return h.writeToStream(h.swapStream, msg)
+	return h.writeToStream(swap.stream, msg)
 }
 
 func (h *host) getBootnodes() []peer.AddrInfo {
@@ -29,6 +29,18 @@ func (s *mockSwapState) HandleProtocolMessage(msg Message) (resp Message, done b
 	return nil, false, nil
 }
 
+func (s *mockSwapState) SendKeysMessage() (*SendKeysMessage, error) {
+	return &SendKeysMessage{}, nil
+}
+
+func (s *mockSwapState) ID() uint64 {
+	return 0
+}
+
+func (s *mockSwapState) InfoFile() string {
+	return ""
+}
+
 func (s *mockSwapState) Exit() error {
 	return nil
 }
@@ -18,11 +18,17 @@ const (
 	protocolTimeout = time.Second * 5
 )
 
+// swap is a swap which is currently being executed with a peer, and the stream used for it.
+type swap struct {
+	swapState SwapState
+	stream    libp2pnetwork.Stream
+}
+
 func (h *host) Initiate(who peer.AddrInfo, msg *SendKeysMessage, s common.SwapState) error {
 	h.swapMu.Lock()
 	defer h.swapMu.Unlock()
 
-	if h.swapState != nil {
+	if _, has := h.swaps[s.ID()]; has {
 		return errSwapAlreadyInProgress
 	}
 
@@ -47,9 +53,12 @@ func (h *host) Initiate(who peer.AddrInfo, msg *SendKeysMessage, s common.SwapSt
 		return err
 	}
 
-	h.swapState = s
-	h.swapStream = stream
-	go h.handleProtocolStreamInner(stream)
+	h.swaps[s.ID()] = &swap{
+		swapState: s,
+		stream:    stream,
+	}
+
+	go h.handleProtocolStreamInner(stream, s)
 	return nil
 }
 
@@ -59,29 +68,27 @@ func (h *host) handleProtocolStream(stream libp2pnetwork.Stream) {
 		return
 	}
 
-	h.swapMu.Lock()
-	defer h.swapMu.Unlock()
-
-	if h.swapState != nil {
-		log.Debug("failed to handling incoming swap stream, already have ongoing swap")
-	}
-
-	h.swapStream = stream
-	h.handleProtocolStreamInner(stream)
+	h.handleProtocolStreamInner(stream, nil)
 }
 
 // handleProtocolStreamInner is called to handle a protocol stream, in both ingoing and outgoing cases.
-func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
+// For incoming streams, the swap state is nil until the initiating SendKeysMessage has been handled.
+func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream, s SwapState) {
 	defer func() {
 		log.Debugf("closing stream: peer=%s protocol=%s", stream.Conn().RemotePeer(), stream.Protocol())
 		_ = stream.Close()
-		if h.swapState != nil {
-			log.Debugf("exiting swap...")
-			if err := h.swapState.Exit(); err != nil {
-				log.Errorf("failed to exit protocol: err=%s", err)
-			}
-			h.swapState = nil
+		if s == nil {
+			return
+		}
+
+		log.Debugf("exiting swap...")
+		if err := s.Exit(); err != nil {
+			log.Errorf("failed to exit protocol: err=%s", err)
 		}
+
+		h.swapMu.Lock()
+		delete(h.swaps, s.ID())
+		h.swapMu.Unlock()
 	}()
 
 	msgBytes := make([]byte, 1<<17)
@@ -109,23 +116,29 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 			done bool
 		)
 
-		if h.swapState == nil {
+		if s == nil {
 			im, ok := msg.(*SendKeysMessage)
 			if !ok {
 				log.Warnf("failed to handle protocol message: message was not SendKeysMessage")
 				return
 			}
 
-			var s SwapState
-			s, resp, err = h.handler.HandleInitiateMessage(im)
+			var ss SwapState
+			ss, resp, err = h.handler.HandleInitiateMessage(im)
 			if err != nil {
 				log.Warnf("failed to handle protocol message: err=%s", err)
 				return
 			}
 
-			h.swapState = s
+			s = ss
+			h.swapMu.Lock()
+			h.swaps[s.ID()] = &swap{
+				swapState: s,
+				stream:    stream,
+			}
+			h.swapMu.Unlock()
 		} else {
-			resp, done, err = h.swapState.HandleProtocolMessage(msg)
+			resp, done, err = s.HandleProtocolMessage(msg)
 			if err != nil {
 				log.Warnf("failed to handle protocol message: err=%s", err)
 				return
@@ -148,9 +161,15 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 	}
 }
 
-// CloseProtocolStream closes the current swap protocol stream.
-func (h *host) CloseProtocolStream() {
-	stream := h.swapStream
-	log.Debugf("closing stream: peer=%s protocol=%s", stream.Conn().RemotePeer(), stream.Protocol())
-	_ = stream.Close()
+// CloseProtocolStream closes the protocol stream of the swap with the given ID.
+func (h *host) CloseProtocolStream(id uint64) {
+	h.swapMu.Lock()
+	swap, has := h.swaps[id]
+	h.swapMu.Unlock()
+	if !has {
+		return
+	}
+
+	log.Debugf("closing stream: peer=%s protocol=%s", swap.stream.Conn().RemotePeer(), swap.stream.Protocol())
+	_ = swap.stream.Close()
 }
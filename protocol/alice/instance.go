@@ -15,6 +15,7 @@ import (
 
 	"github.com/noot/atomic-swap/common"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/hooks"
 	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
 	"github.com/noot/atomic-swap/protocol/swap"
@@ -52,7 +53,8 @@ type Instance struct {
 	gasLimit    uint64
 	swapTimeout time.Duration
 
-	net net.MessageSender
+	net   net.MessageSender
+	hooks *hooks.Engine
 
 	// non-nil if a swap is currently happening, nil otherwise
 	swapMu    sync.Mutex
@@ -79,6 +81,7 @@ type Config struct {
 	GasPrice                               *big.Int
 	GasLimit                               uint64
 	SwapManager                            *swap.Manager
+	Hooks                                  *hooks.Engine // optional
 }
 
 // NewInstance returns a new instance of Alice.
@@ -131,6 +134,7 @@ func NewInstance(cfg *Config) (*Instance, error) {
 		contract:     cfg.SwapContract,
 		contractAddr: cfg.SwapContractAddress,
 		swapTimeout:  defaultTimeoutDuration,
+		hooks:        cfg.Hooks,
 	}, nil
 }
 
@@ -188,8 +192,15 @@ func (a *Instance) Refund() (ethcommon.Hash, error) {
 	return a.swapState.doRefund()
 }
 
-// GetOngoingSwapState ...
-func (a *Instance) GetOngoingSwapState() common.SwapState {
+// GetOngoingSwapState returns the state of the ongoing swap with the given ID, if there is one.
+func (a *Instance) GetOngoingSwapState(id uint64) common.SwapState {
+	a.swapMu.Lock()
+	defer a.swapMu.Unlock()
+
+	if a.swapState == nil || a.swapState.ID() != id {
+		return nil
+	}
+
 	return a.swapState
 }
 
@@ -63,6 +63,12 @@ func (s *swapState) clearNextExpectedMessage(status types.Status) {
 	if s.statusCh != nil {
 		s.statusCh <- status
 	}
+
+	if err := pcommon.WriteSwapStatusToFile(s.infofile, status); err != nil {
+		log.Warnf("failed to write swap status to infofile: %s", err)
+	}
+
+	s.alice.hooks.OnStatus(s.ID(), status)
 }
 
 func (s *swapState) setNextExpectedMessage(msg net.Message) {
@@ -81,6 +87,12 @@ func (s *swapState) setNextExpectedMessage(msg net.Message) {
 	if s.statusCh != nil {
 		s.statusCh <- stage
 	}
+
+	if err := pcommon.WriteSwapStatusToFile(s.infofile, stage); err != nil {
+		log.Warnf("failed to write swap status to infofile: %s", err)
+	}
+
+	s.alice.hooks.OnStatus(s.ID(), stage)
 }
 
 func (s *swapState) checkMessageType(msg net.Message) error {
@@ -199,7 +211,7 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message
 			// send NotifyRefund msg
 			if err := s.alice.net.SendSwapMessage(&message.NotifyRefund{
 				TxHash: txhash.String(),
-			}); err != nil {
+			}, s.ID()); err != nil {
 				log.Errorf("failed to send refund message: err=%s", err)
 			}
 		case <-s.xmrLockedCh:
@@ -339,7 +351,7 @@ func (s *swapState) handleNotifyXMRLock(msg *message.NotifyXMRLock) (net.Message
 			// send NotifyRefund msg
 			if err = s.alice.net.SendSwapMessage(&message.NotifyRefund{
 				TxHash: txhash.String(),
-			}); err != nil {
+			}, s.ID()); err != nil {
 				log.Errorf("failed to send refund message: err=%s", err)
 			}
 
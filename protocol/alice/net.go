@@ -1,8 +1,11 @@
 package alice
 
 import (
+	"fmt"
+
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 	pcommon "github.com/noot/atomic-swap/protocol"
 
 	"github.com/fatih/color" //nolint:misspell
@@ -14,11 +17,20 @@ func (a *Instance) Provides() types.ProvidesCoin {
 }
 
 // InitiateProtocol is called when an RPC call is made from the user to initiate a swap.
-// The input units are ether that we will provide.
-func (a *Instance) InitiateProtocol(providesAmount float64, offer *types.Offer) (common.SwapState, error) {
+// The input units are ether that we will provide. If a payout is given, the swap is executed on
+// behalf of a customer, and the monero received is sent to the payout address. If a callback URL
+// is given, the swap's result is sent to it once the swap completes.
+func (a *Instance) InitiateProtocol(providesAmount float64, offer *types.Offer,
+	payout *types.Payout, callbackURL string) (common.SwapState, error) {
+	if payout != nil && payout.Address != "" {
+		if err := mcrypto.Address(payout.Address).ValidateEnv(a.env); err != nil {
+			return nil, fmt.Errorf("invalid payout address: %w", err)
+		}
+	}
+
 	receivedAmount := offer.ExchangeRate.ToXMR(providesAmount)
 	err := a.initiate(common.EtherToWei(providesAmount), common.MoneroToPiconero(receivedAmount),
-		offer.ExchangeRate)
+		offer.ExchangeRate, payout, callbackURL)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +39,7 @@ func (a *Instance) InitiateProtocol(providesAmount float64, offer *types.Offer)
 }
 
 func (a *Instance) initiate(providesAmount common.EtherAmount, receivedAmount common.MoneroAmount,
-	exchangeRate types.ExchangeRate) error {
+	exchangeRate types.ExchangeRate, payout *types.Payout, callbackURL string) error {
 	a.swapMu.Lock()
 	defer a.swapMu.Unlock()
 
@@ -46,7 +58,7 @@ func (a *Instance) initiate(providesAmount common.EtherAmount, receivedAmount co
 	}
 
 	a.swapState, err = newSwapState(a, pcommon.GetSwapInfoFilepath(a.basepath), providesAmount,
-		receivedAmount, exchangeRate)
+		receivedAmount, exchangeRate, payout, callbackURL)
 	if err != nil {
 		return err
 	}
@@ -12,7 +12,20 @@ func TestAlice_InitiateProtocol(t *testing.T) {
 	a := newTestAlice(t)
 	s, err := a.InitiateProtocol(3.33, &types.Offer{
 		ExchangeRate: 1,
-	})
+	}, nil, "")
 	require.NoError(t, err)
 	require.Equal(t, a.swapState, s)
+	require.Equal(t, a.swapState, a.GetOngoingSwapState(s.ID()))
+}
+
+func TestAlice_InitiateProtocol_InvalidPayoutAddress(t *testing.T) {
+	a := newTestAlice(t)
+	_, err := a.InitiateProtocol(3.33, &types.Offer{
+		ExchangeRate: 1,
+	}, &types.Payout{
+		Label:   "customer",
+		Address: "0xabcd",
+	}, "")
+	require.Error(t, err)
+	require.Nil(t, a.swapState)
 }
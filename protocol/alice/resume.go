@@ -0,0 +1,250 @@
+package alice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/net"
+	"github.com/noot/atomic-swap/net/message"
+	pcommon "github.com/noot/atomic-swap/protocol"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swapfactory"
+)
+
+var resumePollInterval = time.Minute
+
+// ResumeSwaps resumes the swaps which were ongoing when the daemon last exited.
+// The protocol streams of these swaps didn't survive the restart, so each swap is completed by
+// monitoring the contract instead: if Bob claimed, our monero is claimed using the secret he revealed,
+// otherwise our ether is refunded once possible. Swaps where we hadn't yet locked our ether are aborted.
+func (a *Instance) ResumeSwaps() error {
+	swaps, err := pcommon.GetResumableSwaps(a.basepath, types.ProvidesETH)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range swaps {
+		if rs.ContractAddress != "" && ethcommon.HexToAddress(rs.ContractAddress) != a.contractAddr {
+			log.Errorf("cannot resume swap from %s: it uses contract %s, but we're using %s",
+				rs.InfoFile, rs.ContractAddress, a.contractAddr)
+			continue
+		}
+
+		s, err := newResumedSwapState(a, rs)
+		if err != nil {
+			log.Errorf("failed to resume swap from %s: %s", rs.InfoFile, err)
+			continue
+		}
+
+		log.Infof("resuming swap from %s: id=%d status=%s", rs.InfoFile, s.ID(), rs.Status)
+		go s.runResumed()
+	}
+
+	return nil
+}
+
+func newResumedSwapState(a *Instance, rs *pcommon.ResumableSwap) (*swapState, error) {
+	txOpts, err := bind.NewKeyedTransactorWithChainID(a.ethPrivKey, a.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	txOpts.GasPrice = a.gasPrice
+	txOpts.GasLimit = a.gasLimit
+
+	statusCh := make(chan types.Status, 16)
+	statusCh <- rs.Status
+	info := pswap.NewInfo(types.ProvidesETH, rs.ProvidedAmount, rs.ReceivedAmount,
+		rs.ExchangeRate, rs.Status, statusCh)
+	info.SetPayout(rs.Payout)
+	info.SetCallbackURL(rs.CallbackURL)
+	if err = a.swapManager.AddSwap(info); err != nil {
+		return nil, err
+	}
+
+	if err = pcommon.WriteSwapIDToFile(rs.InfoFile, info.ID()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	s := &swapState{
+		ctx:            ctx,
+		cancel:         cancel,
+		alice:          a,
+		infofile:       rs.InfoFile,
+		info:           info,
+		statusCh:       statusCh,
+		txOpts:         txOpts,
+		contractSwapID: rs.ContractSwapID,
+		xmrLockedCh:    make(chan struct{}),
+		claimedCh:      make(chan struct{}),
+		// if we exit before our ether was locked, the swap is simply aborted
+		nextExpectedMessage: &net.SendKeysMessage{},
+	}
+
+	if rs.NewSwapTxHash != "" {
+		s.newSwapTxHash = ethcommon.HexToHash(rs.NewSwapTxHash)
+	}
+
+	if rs.Secret == nil || (rs.ContractSwapID == nil && rs.NewSwapTxHash == "") {
+		return s, nil
+	}
+
+	kp, err := rs.Secret.AsPrivateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	var sc [32]byte
+	copy(sc[:], rs.Secret.Bytes())
+	s.privkeys = kp
+	s.pubkeys = kp.PublicKeyPair()
+	s.dleqProof = dleq.NewProofWithSecret(sc)
+	s.nextExpectedMessage = &message.NotifyClaimed{}
+	return s, nil
+}
+
+// runResumed polls the contract until the resumed swap completes, or the swap is exited.
+func (s *swapState) runResumed() {
+	for {
+		s.Lock()
+		if s.ctx.Err() != nil {
+			s.Unlock()
+			return
+		}
+
+		done, err := s.resolveOnChain()
+		if err != nil {
+			log.Warnf("failed to resolve resumed swap %d: %s", s.ID(), err)
+		}
+		s.Unlock()
+
+		if done {
+			_ = s.Exit()
+			return
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(resumePollInterval):
+		}
+	}
+}
+
+// resolveOnChain checks the state of the swap in the contract, and claims our monero or refunds
+// our ether if possible. It returns true once there's nothing left to wait for.
+//
+// As Bob's keys weren't persisted, we can't check whether he locked his monero, so we never call
+// Ready(). If Bob doesn't claim, the ether is refunded as soon as the contract allows it.
+func (s *swapState) resolveOnChain() (bool, error) {
+	if s.privkeys == nil {
+		log.Infof("ether for swap %d was not locked before exiting, aborting swap", s.ID())
+		return true, nil
+	}
+
+	if s.contractSwapID == nil {
+		locked, err := s.findContractSwapID()
+		if err != nil {
+			return false, err
+		}
+
+		if !locked {
+			log.Infof("ether for swap %d was not locked before exiting, aborting swap", s.ID())
+			s.nextExpectedMessage = &net.SendKeysMessage{}
+			return true, nil
+		}
+	}
+
+	if err := s.setTimeouts(); err != nil {
+		return false, err
+	}
+
+	// if Bob claimed, his secret lets us claim the monero
+	skB, err := s.filterForClaim()
+	if err == nil {
+		vkB, err := skB.View() //nolint:govet
+		if err != nil {
+			return false, err
+		}
+
+		s.setBobKeys(skB.Public(), vkB, nil)
+		addr, err := s.claimMonero(skB)
+		if err != nil {
+			return false, err
+		}
+
+		log.Infof("claimed monero: address=%s", addr)
+		s.clearNextExpectedMessage(types.CompletedSuccess)
+		return true, nil
+	}
+
+	if !errors.Is(err, errNoClaimLogsFound) {
+		return false, err
+	}
+
+	swap, err := s.alice.contract.Swaps(s.alice.callOpts, s.contractSwapID)
+	if err != nil {
+		return false, err
+	}
+
+	if swap.Completed {
+		// Bob didn't claim, so we refunded before exiting
+		s.clearNextExpectedMessage(types.CompletedRefund)
+		return true, nil
+	}
+
+	now := time.Now()
+	if (swap.IsReady || !now.Before(s.t0)) && now.Before(s.t1) {
+		// Bob is able to claim until t1, after which we can refund
+		return false, nil
+	}
+
+	txHash, err := s.refund()
+	if err != nil {
+		return false, err
+	}
+
+	log.Infof("refunded ether: transaction hash=%s", txHash)
+	return true, nil
+}
+
+// findContractSwapID finds the contract's swap ID from the NewSwap transaction sent before exiting.
+// It returns false if the transaction didn't lock our ether.
+func (s *swapState) findContractSwapID() (bool, error) {
+	if (s.newSwapTxHash == ethcommon.Hash{}) {
+		return false, nil
+	}
+
+	receipt, err := common.WaitForReceipt(s.ctx, s.alice.ethClient, s.newSwapTxHash)
+	if err != nil {
+		// the transaction was likely dropped; if it's included later, the ether can be
+		// refunded using the recovery module.
+		log.Warnf("failed to get receipt for NewSwap transaction %s: %s", s.newSwapTxHash, err)
+		return false, nil
+	}
+
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful || len(receipt.Logs) == 0 {
+		return false, nil
+	}
+
+	s.contractSwapID, err = swapfactory.GetIDFromLog(receipt.Logs[0])
+	if err != nil {
+		return false, fmt.Errorf("failed to get swap ID from NewSwap transaction: %w", err)
+	}
+
+	if err = s.writeCheckpoint(); err != nil {
+		log.Warnf("failed to write checkpoint to infofile: %s", err)
+	}
+
+	return true, nil
+}
@@ -54,6 +54,7 @@ type swapState struct {
 
 	// swap contract and timeouts in it; set once contract is deployed
 	contractSwapID *big.Int
+	newSwapTxHash  ethcommon.Hash
 	t0, t1         time.Time
 	txOpts         *bind.TransactOpts
 
@@ -66,7 +67,8 @@ type swapState struct {
 }
 
 func newSwapState(a *Instance, infofile string, providesAmount common.EtherAmount,
-	receivedAmount common.MoneroAmount, exhangeRate types.ExchangeRate) (*swapState, error) {
+	receivedAmount common.MoneroAmount, exhangeRate types.ExchangeRate, payout *types.Payout,
+	callbackURL string) (*swapState, error) {
 	if a.contract == nil {
 		return nil, errNoSwapContractSet
 	}
@@ -84,6 +86,8 @@ func newSwapState(a *Instance, infofile string, providesAmount common.EtherAmoun
 	statusCh <- stage
 	info := pswap.NewInfo(types.ProvidesETH, providesAmount.AsEther(), receivedAmount.AsMonero(),
 		exhangeRate, stage, statusCh)
+	info.SetPayout(payout)
+	info.SetCallbackURL(callbackURL)
 	if err := a.swapManager.AddSwap(info); err != nil {
 		return nil, err
 	}
@@ -110,11 +114,44 @@ func newSwapState(a *Instance, infofile string, providesAmount common.EtherAmoun
 		return nil, fmt.Errorf("failed to write contract address to file: %w", err)
 	}
 
+	if payout != nil {
+		if err := pcommon.WritePayoutToFile(infofile, payout); err != nil {
+			return nil, err
+		}
+	}
+
+	if callbackURL != "" {
+		if err := pcommon.WriteCallbackURLToFile(infofile, callbackURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.writeCheckpoint(); err != nil {
+		return nil, err
+	}
+
 	go s.waitForSendKeysMessage()
 
 	return s, nil
 }
 
+// writeCheckpoint writes the state needed to resume the swap after a restart to the infofile.
+func (s *swapState) writeCheckpoint() error {
+	cp := &pcommon.SwapCheckpoint{
+		Provides:       types.ProvidesETH,
+		ProvidedAmount: s.info.ProvidedAmount(),
+		ReceivedAmount: s.info.ReceivedAmount(),
+		ExchangeRate:   s.info.ExchangeRate(),
+		ContractSwapID: s.contractSwapID,
+	}
+
+	if (s.newSwapTxHash != ethcommon.Hash{}) {
+		cp.NewSwapTxHash = s.newSwapTxHash.String()
+	}
+
+	return pcommon.WriteCheckpointToFile(s.infofile, cp)
+}
+
 func (s *swapState) waitForSendKeysMessage() {
 	waitDuration := time.Minute
 	timer := time.After(waitDuration)
@@ -180,8 +217,10 @@ func (s *swapState) Exit() error {
 	defer func() {
 		// stop all running goroutines
 		s.cancel()
-		s.alice.swapState = nil
-		s.alice.swapManager.CompleteOngoingSwap()
+		if s.alice.swapState == s {
+			s.alice.swapState = nil
+		}
+		s.alice.swapManager.CompleteOngoingSwap(s.ID())
 
 		if s.info.Status() == types.CompletedSuccess {
 			str := color.New(color.Bold).Sprintf("**swap completed successfully: id=%d**", s.info.ID())
@@ -286,7 +325,7 @@ func (s *swapState) doRefund() (ethcommon.Hash, error) {
 		// send NotifyRefund msg
 		if err = s.alice.net.SendSwapMessage(&message.NotifyRefund{
 			TxHash: txHash.String(),
-		}); err != nil {
+		}, s.ID()); err != nil {
 			return ethcommon.Hash{}, fmt.Errorf("failed to send refund message: err=%w", err)
 		}
 
@@ -408,6 +447,13 @@ func (s *swapState) lockETH(amount common.EtherAmount) (ethcommon.Hash, error) {
 	}
 
 	log.Debugf("instantiating swap on-chain: amount=%s txHash=%s", amount, tx.Hash())
+
+	// our ether may now be locked, so make sure we can find the swap again after a restart
+	s.newSwapTxHash = tx.Hash()
+	if err = s.writeCheckpoint(); err != nil {
+		log.Warnf("failed to write checkpoint to infofile: %s", err)
+	}
+
 	receipt, err := common.WaitForReceipt(s.ctx, s.alice.ethClient, tx.Hash())
 	if err != nil {
 		return ethcommon.Hash{}, fmt.Errorf("failed to call new_swap in contract: %w", err)
@@ -422,6 +468,10 @@ func (s *swapState) lockETH(amount common.EtherAmount) (ethcommon.Hash, error) {
 		return ethcommon.Hash{}, err
 	}
 
+	if err = s.writeCheckpoint(); err != nil {
+		log.Warnf("failed to write checkpoint to infofile: %s", err)
+	}
+
 	return tx.Hash(), nil
 }
 
@@ -488,22 +538,26 @@ func (s *swapState) claimMonero(skB *mcrypto.PrivateSpendKey) (mcrypto.Address,
 		return "", err
 	}
 
-	if !s.alice.transferBack {
+	// if the swap is executed on behalf of a customer, the monero is sent to them,
+	// otherwise it's optionally transferred back to our original account
+	sweepTo := s.alice.walletAddress
+	if payout := s.info.Payout(); payout != nil && payout.Address != "" {
+		sweepTo = mcrypto.Address(payout.Address)
+	} else if !s.alice.transferBack {
 		log.Infof("monero claimed in account %s", addr)
 		return addr, nil
 	}
 
-	log.Infof("monero claimed in account %s; transferring to original account %s",
-		addr, s.alice.walletAddress)
+	log.Infof("monero claimed in account %s; transferring to %s", addr, sweepTo)
 
 	err = s.waitUntilBalanceUnlocks()
 	if err != nil {
 		return "", fmt.Errorf("failed to wait for balance to unlock: %w", err)
 	}
 
-	res, err := s.alice.client.SweepAll(s.alice.walletAddress, 0)
+	res, err := s.alice.client.SweepAll(sweepTo, 0)
 	if err != nil {
-		return "", fmt.Errorf("failed to send funds to original account: %w", err)
+		return "", fmt.Errorf("failed to send funds to %s: %w", sweepTo, err)
 	}
 
 	if len(res.AmountList) == 0 {
@@ -513,7 +567,7 @@ func (s *swapState) claimMonero(skB *mcrypto.PrivateSpendKey) (mcrypto.Address,
 	amount := res.AmountList[0]
 	log.Infof("transferred %v XMR to %s",
 		common.MoneroAmount(amount).AsMonero(),
-		s.alice.walletAddress,
+		sweepTo,
 	)
 
 	close(s.claimedCh)
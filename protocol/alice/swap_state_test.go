@@ -34,7 +34,7 @@ type mockNet struct {
 	msg net.Message
 }
 
-func (n *mockNet) SendSwapMessage(msg net.Message) error {
+func (n *mockNet) SendSwapMessage(msg net.Message, _ uint64) error {
 	n.msg = msg
 	return nil
 }
@@ -71,7 +71,7 @@ func newTestAlice(t *testing.T) *Instance {
 
 func newTestInstance(t *testing.T) (*Instance, *swapState) {
 	alice := newTestAlice(t)
-	swapState, err := newSwapState(alice, infofile, common.NewEtherAmount(1), common.MoneroAmount(0), 1)
+	swapState, err := newSwapState(alice, infofile, common.NewEtherAmount(1), common.MoneroAmount(0), 1, nil, "")
 	require.NoError(t, err)
 	return alice, swapState
 }
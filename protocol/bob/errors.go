@@ -22,10 +22,13 @@ var (
 	errInvalidSwapContract       = errors.New("given contract address does not contain correct code")
 
 	// protocol initiation errors
-	errProtocolAlreadyInProgress = errors.New("protocol already in progress")
-	errBalanceTooLow             = errors.New("balance lower than amount to be provided")
-	errNoOfferWithID             = errors.New("failed to find offer with given ID")
-	errAmountProvidedTooLow      = errors.New("amount provided by taker is too low for offer")
-	errAmountProvidedTooHigh     = errors.New("amount provided by taker is too high for offer")
-	errUnlockedBalanceTooLow     = errors.New("unlocked balance is less than maximum offer amount")
+	errBalanceTooLow         = errors.New("balance lower than amount to be provided")
+	errNoOfferWithID         = errors.New("failed to find offer with given ID")
+	errAmountProvidedTooLow  = errors.New("amount provided by taker is too low for offer")
+	errAmountProvidedTooHigh = errors.New("amount provided by taker is too high for offer")
+	errUnlockedBalanceTooLow = errors.New("unlocked balance is less than maximum offer amount")
+	errInvalidPayoutAddress  = errors.New("payout address is not a valid ethereum address")
+
+	// payout errors
+	errPayoutTxFailed = errors.New("payout transaction failed")
 )
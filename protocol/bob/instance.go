@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/hooks"
 	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
 	"github.com/noot/atomic-swap/protocol/swap"
@@ -41,13 +42,24 @@ type Instance struct {
 	gasPrice   *big.Int
 	gasLimit   uint64
 
-	net net.MessageSender
+	net   net.MessageSender
+	hooks *hooks.Engine
 
 	offerManager *offerManager
 	swapManager  *swap.Manager
 
-	swapMu    sync.Mutex
-	swapState *swapState
+	// ongoing swaps, keyed by swap ID, and the amount of XMR reserved for swaps which
+	// have been initiated but haven't yet locked their XMR
+	swapMu     sync.Mutex
+	swapStates map[uint64]*swapState
+	reserved   common.MoneroAmount
+
+	// walletMu serializes operations which use the monero wallet, as it may be switched
+	// away from our wallet when reclaiming monero.
+	walletMu sync.Mutex
+
+	// txMu serializes sending ethereum transactions, so that concurrent swaps don't use the same nonce.
+	txMu sync.Mutex
 }
 
 // Config contains the configuration values for a new Bob instance.
@@ -64,6 +76,7 @@ type Config struct {
 	GasPrice                   *big.Int
 	SwapManager                *swap.Manager
 	GasLimit                   uint64
+	Hooks                      *hooks.Engine // optional
 }
 
 // NewInstance returns a new *bob.Instance.
@@ -111,6 +124,8 @@ func NewInstance(cfg *Config) (*Instance, error) {
 		chainID:      cfg.ChainID,
 		offerManager: newOfferManager(cfg.Basepath),
 		swapManager:  cfg.SwapManager,
+		swapStates:   make(map[uint64]*swapState),
+		hooks:        cfg.Hooks,
 	}, nil
 }
 
@@ -134,7 +149,24 @@ func (b *Instance) openWallet() error { //nolint
 	return b.client.OpenWallet(b.walletFile, b.walletPassword)
 }
 
-// GetOngoingSwapState ...
-func (b *Instance) GetOngoingSwapState() common.SwapState {
-	return b.swapState
+// GetOngoingSwapState returns the state of the ongoing swap with the given ID, if there is one.
+func (b *Instance) GetOngoingSwapState(id uint64) common.SwapState {
+	b.swapMu.Lock()
+	defer b.swapMu.Unlock()
+
+	s, has := b.swapStates[id]
+	if !has {
+		return nil
+	}
+
+	return s
+}
+
+// releaseReservation releases the XMR reserved for the given swap. It is called once the swap's
+// XMR is locked, or the swap exits, and does nothing if the reservation was already released.
+func (b *Instance) releaseReservation(s *swapState) {
+	b.swapMu.Lock()
+	defer b.swapMu.Unlock()
+	b.reserved -= s.reserved
+	s.reserved = 0
 }
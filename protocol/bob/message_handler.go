@@ -86,6 +86,12 @@ func (s *swapState) clearNextExpectedMessage(status types.Status) {
 	if s.statusCh != nil {
 		s.statusCh <- status
 	}
+
+	if err := pcommon.WriteSwapStatusToFile(s.infofile, status); err != nil {
+		log.Warnf("failed to write swap status to infofile: %s", err)
+	}
+
+	s.bob.hooks.OnStatus(s.ID(), status)
 }
 
 func (s *swapState) setNextExpectedMessage(msg net.Message) {
@@ -103,6 +109,12 @@ func (s *swapState) setNextExpectedMessage(msg net.Message) {
 	if s.statusCh != nil {
 		s.statusCh <- stage
 	}
+
+	if err := pcommon.WriteSwapStatusToFile(s.infofile, stage); err != nil {
+		log.Warnf("failed to write swap status to infofile: %s", err)
+	}
+
+	s.bob.hooks.OnStatus(s.ID(), stage)
 }
 
 func (s *swapState) checkMessageType(msg net.Message) error {
@@ -151,6 +163,10 @@ func (s *swapState) handleNotifyETHLocked(msg *message.NotifyETHLocked) (net.Mes
 		return nil, fmt.Errorf("failed to write contract address to file: %w", err)
 	}
 
+	if err := s.writeCheckpoint(); err != nil {
+		return nil, fmt.Errorf("failed to write checkpoint to file: %w", err)
+	}
+
 	if err := s.checkContract(ethcommon.HexToHash(msg.TxHash)); err != nil {
 		return nil, err
 	}
@@ -201,7 +217,7 @@ func (s *swapState) handleNotifyETHLocked(msg *message.NotifyETHLocked) (net.Mes
 			// send *message.NotifyClaimed
 			if err := s.bob.net.SendSwapMessage(&message.NotifyClaimed{
 				TxHash: txHash.String(),
-			}); err != nil {
+			}, s.ID()); err != nil {
 				log.Errorf("failed to send NotifyClaimed message: err=%s", err)
 			}
 		case <-s.readyCh:
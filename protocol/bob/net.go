@@ -15,36 +15,38 @@ func (b *Instance) Provides() types.ProvidesCoin {
 }
 
 func (b *Instance) initiate(offer *types.Offer, offerExtra *types.OfferExtra, providesAmount common.MoneroAmount,
-	desiredAmount common.EtherAmount) error {
+	desiredAmount common.EtherAmount) (*swapState, error) {
 	b.swapMu.Lock()
 	defer b.swapMu.Unlock()
 
-	if b.swapState != nil {
-		return errProtocolAlreadyInProgress
-	}
-
 	balance, err := b.client.GetBalance(0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// check user's balance and that they actually have what they will provide
-	if balance.UnlockedBalance <= float64(providesAmount) {
-		return errBalanceTooLow
+	// check user's balance and that they actually have what they will provide,
+	// not including what's reserved for other ongoing swaps
+	if balance.UnlockedBalance <= float64(b.reserved+providesAmount) {
+		return nil, errBalanceTooLow
 	}
 
-	b.swapState, err = newSwapState(b, offer, offerExtra.StatusCh, offerExtra.InfoFile, providesAmount, desiredAmount)
+	s, err := newSwapState(b, offer, offerExtra.StatusCh, offerExtra.InfoFile, providesAmount, desiredAmount,
+		offerExtra.Payout, offerExtra.CallbackURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", b.swapState.ID()))
+	s.reserved = providesAmount
+	b.reserved += providesAmount
+	b.swapStates[s.ID()] = s
+
+	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", s.ID()))
 	log.Info(color.New(color.Bold).Sprint("DO NOT EXIT THIS PROCESS OR FUNDS MAY BE LOST!"))
 	log.Infof(color.New(color.Bold).Sprintf("receiving %v ETH for %v XMR",
-		b.swapState.info.ReceivedAmount(),
-		b.swapState.info.ProvidedAmount()),
+		s.info.ReceivedAmount(),
+		s.info.ProvidedAmount()),
 	)
-	return nil
+	return s, nil
 }
 
 // HandleInitiateMessage is called when we receive a network message from a peer that they wish to initiate a swap.
@@ -76,22 +78,33 @@ func (b *Instance) HandleInitiateMessage(msg *net.SendKeysMessage) (net.SwapStat
 		return nil, nil, errAmountProvidedTooHigh
 	}
 
-	if err = b.initiate(offer, offerExtra, common.MoneroToPiconero(providedAmount), common.EtherToWei(msg.ProvidedAmount)); err != nil { //nolint:lll
+	if err = b.hooks.OnTake(offer, providedAmount); err != nil {
+		b.offerManager.putOffer(offer, offerExtra.Payout, offerExtra.CallbackURL)
+		return nil, nil, err
+	}
+
+	s, err := b.initiate(offer, offerExtra, common.MoneroToPiconero(providedAmount), common.EtherToWei(msg.ProvidedAmount)) //nolint:lll
+	if err != nil {
 		return nil, nil, err
 	}
 
-	offerExtra.IDCh <- b.swapState.info.ID()
+	offerExtra.IDCh <- s.info.ID()
 	close(offerExtra.IDCh)
 
-	if err = b.swapState.handleSendKeysMessage(msg); err != nil {
+	s.Lock()
+	defer s.Unlock()
+
+	if err = s.handleSendKeysMessage(msg); err != nil {
+		_ = s.exit()
 		return nil, nil, err
 	}
 
-	resp, err := b.swapState.SendKeysMessage()
+	resp, err := s.SendKeysMessage()
 	if err != nil {
+		_ = s.exit()
 		return nil, nil, err
 	}
 
-	defer b.swapState.setNextExpectedMessage(&message.NotifyETHLocked{})
-	return b.swapState, resp, nil
+	defer s.setNextExpectedMessage(&message.NotifyETHLocked{})
+	return s, resp, nil
 }
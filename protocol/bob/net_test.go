@@ -3,6 +3,7 @@ package bob
 import (
 	"testing"
 
+	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
 	"github.com/noot/atomic-swap/net/message"
 
@@ -18,7 +19,7 @@ func TestBob_HandleInitiateMessage(t *testing.T) {
 		MaximumAmount: 0.002,
 		ExchangeRate:  0.1,
 	}
-	extra, err := b.MakeOffer(offer)
+	extra, err := b.MakeOffer(offer, nil, "")
 	require.NoError(t, err)
 	go func() {
 		<-extra.IDCh
@@ -28,8 +29,46 @@ func TestBob_HandleInitiateMessage(t *testing.T) {
 	msg.OfferID = offer.GetID().String()
 	msg.ProvidedAmount = offer.MinimumAmount * float64(offer.ExchangeRate)
 
-	_, resp, err := b.HandleInitiateMessage(msg)
+	s, resp, err := b.HandleInitiateMessage(msg)
 	require.NoError(t, err)
 	require.Equal(t, message.SendKeysType, resp.Type())
-	require.NotNil(t, b.swapState)
+	require.NotNil(t, b.GetOngoingSwapState(s.ID()))
+	require.Equal(t, common.MoneroToPiconero(offer.MinimumAmount), b.reserved)
+}
+
+func TestBob_HandleInitiateMessage_Concurrent(t *testing.T) {
+	b := newTestBob(t)
+
+	var ids []uint64
+	for i := 0; i < 2; i++ {
+		offer := &types.Offer{
+			Provides:      types.ProvidesXMR,
+			MinimumAmount: 0.001,
+			MaximumAmount: 0.002,
+			ExchangeRate:  types.ExchangeRate(0.1 + float64(i)),
+		}
+		extra, err := b.MakeOffer(offer, nil, "")
+		require.NoError(t, err)
+		go func() {
+			<-extra.IDCh
+		}()
+
+		msg, _ := newTestAliceSendKeysMessage(t)
+		msg.OfferID = offer.GetID().String()
+		msg.ProvidedAmount = offer.MinimumAmount * float64(offer.ExchangeRate)
+
+		s, _, err := b.HandleInitiateMessage(msg)
+		require.NoError(t, err)
+		ids = append(ids, s.ID())
+	}
+
+	require.NotEqual(t, ids[0], ids[1])
+	require.Equal(t, 2, len(b.swapStates))
+	require.Equal(t, 2*common.MoneroToPiconero(0.001), b.reserved)
+
+	// exiting one swap releases its reservation, but leaves the other swap intact
+	require.NoError(t, b.GetOngoingSwapState(ids[0]).Exit())
+	require.Nil(t, b.GetOngoingSwapState(ids[0]))
+	require.NotNil(t, b.GetOngoingSwapState(ids[1]))
+	require.Equal(t, common.MoneroToPiconero(0.001), b.reserved)
 }
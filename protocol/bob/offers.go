@@ -4,6 +4,8 @@ import (
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
 	pcommon "github.com/noot/atomic-swap/protocol"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 type offerWithExtra struct {
@@ -23,16 +25,18 @@ func newOfferManager(basepath string) *offerManager {
 	}
 }
 
-func (om *offerManager) putOffer(o *types.Offer) *types.OfferExtra {
+func (om *offerManager) putOffer(o *types.Offer, payout *types.Payout, callbackURL string) *types.OfferExtra {
 	offer, has := om.offers[o.GetID()]
 	if has {
 		return offer.extra
 	}
 
 	extra := &types.OfferExtra{
-		IDCh:     make(chan uint64, 1),
-		StatusCh: make(chan types.Status, 7),
-		InfoFile: pcommon.GetSwapInfoFilepath(om.basepath),
+		IDCh:        make(chan uint64, 1),
+		StatusCh:    make(chan types.Status, 7),
+		InfoFile:    pcommon.GetSwapInfoFilepath(om.basepath),
+		Payout:      payout,
+		CallbackURL: callbackURL,
 	}
 
 	oe := &offerWithExtra{
@@ -54,8 +58,13 @@ func (om *offerManager) getAndDeleteOffer(id types.Hash) (*types.Offer, *types.O
 	return offer.offer, offer.extra
 }
 
-// MakeOffer makes a new swap offer.
-func (b *Instance) MakeOffer(o *types.Offer) (*types.OfferExtra, error) {
+// MakeOffer makes a new swap offer. If a callback URL is given, the result of each swap of the
+// offer is sent to it once the swap completes.
+func (b *Instance) MakeOffer(o *types.Offer, payout *types.Payout, callbackURL string) (*types.OfferExtra, error) {
+	if payout != nil && payout.Address != "" && !ethcommon.IsHexAddress(payout.Address) {
+		return nil, errInvalidPayoutAddress
+	}
+
 	balance, err := b.client.GetBalance(0)
 	if err != nil {
 		return nil, err
@@ -65,7 +74,11 @@ func (b *Instance) MakeOffer(o *types.Offer) (*types.OfferExtra, error) {
 		return nil, errUnlockedBalanceTooLow
 	}
 
-	extra := b.offerManager.putOffer(o)
+	if err = b.hooks.OnMake(o); err != nil {
+		return nil, err
+	}
+
+	extra := b.offerManager.putOffer(o, payout, callbackURL)
 	log.Infof("created new offer: %v", o)
 	return extra, nil
 }
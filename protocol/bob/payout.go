@@ -0,0 +1,66 @@
+package bob
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/noot/atomic-swap/common"
+)
+
+const transferGasLimit = 21000
+
+// forwardPayout sends the given amount of ether to the swap's payout address. It's used for swaps
+// executed on behalf of a customer; the transaction fee is paid from our own account.
+func (s *swapState) forwardPayout(amount *big.Int) (ethcommon.Hash, error) {
+	to := ethcommon.HexToAddress(s.info.Payout().Address)
+
+	gasPrice := s.txOpts.GasPrice
+	if gasPrice == nil {
+		var err error
+		gasPrice, err = s.bob.ethClient.SuggestGasPrice(s.ctx)
+		if err != nil {
+			return ethcommon.Hash{}, err
+		}
+	}
+
+	s.bob.txMu.Lock()
+	nonce, err := s.bob.ethClient.PendingNonceAt(s.ctx, s.bob.ethAddress)
+	if err != nil {
+		s.bob.txMu.Unlock()
+		return ethcommon.Hash{}, err
+	}
+
+	tx := ethtypes.NewTransaction(nonce, to, amount, transferGasLimit, gasPrice, nil)
+	signedTx, err := ethtypes.SignTx(tx, ethtypes.LatestSignerForChainID(s.bob.chainID), s.bob.ethPrivKey)
+	if err != nil {
+		s.bob.txMu.Unlock()
+		return ethcommon.Hash{}, err
+	}
+
+	err = s.bob.ethClient.SendTransaction(s.ctx, signedTx)
+	s.bob.txMu.Unlock()
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	log.Infof("sent payout of %v ETH to %s (label=%q), tx hash=%s",
+		common.EtherAmount(*amount).AsEther(),
+		to,
+		s.info.Payout().Label,
+		signedTx.Hash(),
+	)
+
+	receipt, err := common.WaitForReceipt(s.ctx, s.bob.ethClient, signedTx.Hash())
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to check payout transaction receipt: %w", err)
+	}
+
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return ethcommon.Hash{}, errPayoutTxFailed
+	}
+
+	return signedTx.Hash(), nil
+}
@@ -0,0 +1,193 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/net/message"
+	pcommon "github.com/noot/atomic-swap/protocol"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+)
+
+var resumePollInterval = time.Minute
+
+// ResumeSwaps resumes the swaps which were ongoing when the daemon last exited.
+// The protocol streams of these swaps didn't survive the restart, so each swap is completed by
+// monitoring the contract instead: if the swap was refunded, our monero is reclaimed, otherwise the
+// ether is claimed once possible. Swaps where we hadn't yet locked our monero are aborted.
+func (b *Instance) ResumeSwaps() error {
+	swaps, err := pcommon.GetResumableSwaps(b.basepath, types.ProvidesXMR)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range swaps {
+		s, err := newResumedSwapState(b, rs)
+		if err != nil {
+			log.Errorf("failed to resume swap from %s: %s", rs.InfoFile, err)
+			continue
+		}
+
+		log.Infof("resuming swap from %s: id=%d status=%s", rs.InfoFile, s.ID(), rs.Status)
+
+		b.swapMu.Lock()
+		b.swapStates[s.ID()] = s
+		b.swapMu.Unlock()
+
+		go s.runResumed()
+	}
+
+	return nil
+}
+
+func newResumedSwapState(b *Instance, rs *pcommon.ResumableSwap) (*swapState, error) {
+	txOpts, err := bind.NewKeyedTransactorWithChainID(b.ethPrivKey, b.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	txOpts.GasPrice = b.gasPrice
+	txOpts.GasLimit = b.gasLimit
+
+	statusCh := make(chan types.Status, 7)
+	statusCh <- rs.Status
+	info := pswap.NewInfo(types.ProvidesXMR, rs.ProvidedAmount, rs.ReceivedAmount,
+		rs.ExchangeRate, rs.Status, statusCh)
+	info.SetPayout(rs.Payout)
+	info.SetCallbackURL(rs.CallbackURL)
+	if err = b.swapManager.AddSwap(info); err != nil {
+		return nil, err
+	}
+
+	if err = pcommon.WriteSwapIDToFile(rs.InfoFile, info.ID()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(b.ctx)
+	s := &swapState{
+		ctx:            ctx,
+		cancel:         cancel,
+		bob:            b,
+		infofile:       rs.InfoFile,
+		info:           info,
+		statusCh:       statusCh,
+		readyCh:        make(chan struct{}),
+		txOpts:         txOpts,
+		contractSwapID: rs.ContractSwapID,
+		xmrLocked:      rs.XMRLocked,
+		// if we exit before our monero was locked, the swap is simply aborted
+		nextExpectedMessage: &message.NotifyETHLocked{},
+	}
+
+	if !rs.XMRLocked || rs.Secret == nil || rs.ContractSwapID == nil || rs.ContractAddress == "" {
+		return s, nil
+	}
+
+	kp, err := rs.Secret.AsPrivateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	var sc [32]byte
+	copy(sc[:], rs.Secret.Bytes())
+	s.privkeys = kp
+	s.pubkeys = kp.PublicKeyPair()
+	s.dleqProof = dleq.NewProofWithSecret(sc)
+	s.nextExpectedMessage = &message.NotifyReady{}
+
+	if err = s.setContract(ethcommon.HexToAddress(rs.ContractAddress)); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// runResumed polls the contract until the resumed swap completes, or the swap is exited.
+func (s *swapState) runResumed() {
+	for {
+		s.Lock()
+		if s.ctx.Err() != nil {
+			s.Unlock()
+			return
+		}
+
+		done, err := s.resolveOnChain()
+		if err != nil {
+			log.Warnf("failed to resolve resumed swap %d: %s", s.ID(), err)
+		}
+
+		if done {
+			_ = s.exit()
+			s.Unlock()
+			return
+		}
+		s.Unlock()
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(resumePollInterval):
+		}
+	}
+}
+
+// resolveOnChain checks the state of the swap in the contract, and claims our ether or reclaims
+// our monero if possible. It returns true once there's nothing left to wait for.
+func (s *swapState) resolveOnChain() (bool, error) {
+	if !s.xmrLocked || s.contract == nil {
+		// nothing of ours is locked, so we can walk away. the counterparty will refund.
+		log.Infof("monero for swap %d was not locked before exiting, aborting swap", s.ID())
+		return true, nil
+	}
+
+	if (s.t0 == time.Time{}) {
+		if err := s.setTimeouts(); err != nil {
+			return false, err
+		}
+	}
+
+	// if Alice refunded, her secret lets us regain control of the locked monero
+	address, err := s.tryReclaimMonero()
+	if err == nil {
+		s.moneroReclaimAddress = address
+		s.clearNextExpectedMessage(types.CompletedRefund)
+		log.Infof("regained private key to monero wallet, address=%s", address)
+		return true, nil
+	}
+
+	if !errors.Is(err, errNoRefundLogsFound) {
+		return false, err
+	}
+
+	swap, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
+	if err != nil {
+		return false, err
+	}
+
+	if swap.Completed {
+		// the swap wasn't refunded, so we claimed before exiting
+		s.clearNextExpectedMessage(types.CompletedSuccess)
+		return true, nil
+	}
+
+	now := time.Now()
+	if !now.Before(s.t1) || (!swap.IsReady && now.Before(s.t0)) {
+		// we either can't claim yet, or can no longer claim and must wait for Alice to refund
+		return false, nil
+	}
+
+	txHash, err := s.claimFunds()
+	if err != nil {
+		return false, err
+	}
+
+	log.Infof("claimed ether! transaction hash=%s", txHash)
+	s.clearNextExpectedMessage(types.CompletedSuccess)
+	return true, nil
+}
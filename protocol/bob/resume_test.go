@@ -0,0 +1,43 @@
+package bob
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/noot/atomic-swap/common/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapState_ResolveOnChain_NotLocked(t *testing.T) {
+	_, swapState := newTestInstance(t)
+
+	done, err := swapState.resolveOnChain()
+	require.NoError(t, err)
+	require.True(t, done)
+}
+
+func TestSwapState_ResolveOnChain_Claim(t *testing.T) {
+	bob, swapState := newTestInstance(t)
+	err := swapState.generateAndSetKeys()
+	require.NoError(t, err)
+
+	claimKey := swapState.secp256k1Pub.Keccak256()
+	swapState.contractAddr, _, swapState.contract = newSwap(t, bob, swapState, claimKey,
+		[32]byte{}, big.NewInt(33), defaultTimeoutDuration)
+	swapState.contractSwapID = defaultContractSwapID
+	swapState.xmrLocked = true
+
+	// before t0, and the contract isn't ready, so we can't claim yet
+	done, err := swapState.resolveOnChain()
+	require.NoError(t, err)
+	require.False(t, done)
+
+	_, err = swapState.contract.SetReady(swapState.txOpts, defaultContractSwapID)
+	require.NoError(t, err)
+
+	done, err = swapState.resolveOnChain()
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, types.CompletedSuccess, swapState.info.Status())
+}
@@ -50,6 +50,10 @@ type swapState struct {
 	offer    *types.Offer
 	statusCh chan types.Status
 
+	// amount of XMR reserved for this swap, until it's locked
+	reserved  common.MoneroAmount
+	xmrLocked bool
+
 	// our keys for this session
 	dleqProof    *dleq.Proof
 	secp256k1Pub *secp256k1.PublicKey
@@ -78,7 +82,8 @@ type swapState struct {
 }
 
 func newSwapState(b *Instance, offer *types.Offer, statusCh chan types.Status, infofile string,
-	providesAmount common.MoneroAmount, desiredAmount common.EtherAmount) (*swapState, error) {
+	providesAmount common.MoneroAmount, desiredAmount common.EtherAmount, payout *types.Payout,
+	callbackURL string) (*swapState, error) {
 	txOpts, err := bind.NewKeyedTransactorWithChainID(b.ethPrivKey, b.chainID)
 	if err != nil {
 		return nil, err
@@ -95,6 +100,8 @@ func newSwapState(b *Instance, offer *types.Offer, statusCh chan types.Status, i
 	statusCh <- stage
 	info := pswap.NewInfo(types.ProvidesXMR, providesAmount.AsMonero(), desiredAmount.AsEther(),
 		exchangeRate, stage, statusCh)
+	info.SetPayout(payout)
+	info.SetCallbackURL(callbackURL)
 	if err := b.swapManager.AddSwap(info); err != nil {
 		return nil, err
 	}
@@ -117,9 +124,37 @@ func newSwapState(b *Instance, offer *types.Offer, statusCh chan types.Status, i
 		return nil, err
 	}
 
+	if payout != nil {
+		if err := pcommon.WritePayoutToFile(infofile, payout); err != nil {
+			return nil, err
+		}
+	}
+
+	if callbackURL != "" {
+		if err := pcommon.WriteCallbackURLToFile(infofile, callbackURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.writeCheckpoint(); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
+// writeCheckpoint writes the state needed to resume the swap after a restart to the infofile.
+func (s *swapState) writeCheckpoint() error {
+	return pcommon.WriteCheckpointToFile(s.infofile, &pcommon.SwapCheckpoint{
+		Provides:       types.ProvidesXMR,
+		ProvidedAmount: s.info.ProvidedAmount(),
+		ReceivedAmount: s.info.ReceivedAmount(),
+		ExchangeRate:   s.info.ExchangeRate(),
+		ContractSwapID: s.contractSwapID,
+		XMRLocked:      s.xmrLocked,
+	})
+}
+
 // SendKeysMessage ...
 func (s *swapState) SendKeysMessage() (*net.SendKeysMessage, error) {
 	if err := s.generateAndSetKeys(); err != nil {
@@ -174,12 +209,15 @@ func (s *swapState) exit() error {
 	defer func() {
 		// stop all running goroutines
 		s.cancel()
-		s.bob.swapState = nil
-		s.bob.swapManager.CompleteOngoingSwap()
+		s.bob.releaseReservation(s)
+		s.bob.swapMu.Lock()
+		delete(s.bob.swapStates, s.ID())
+		s.bob.swapMu.Unlock()
+		s.bob.swapManager.CompleteOngoingSwap(s.ID())
 
-		if s.info.Status() != types.CompletedSuccess {
+		if s.offer != nil && s.info.Status() != types.CompletedSuccess {
 			// re-add offer, as it wasn't taken successfully
-			s.bob.offerManager.putOffer(s.offer)
+			s.bob.offerManager.putOffer(s.offer, s.info.Payout(), s.info.CallbackURL())
 		}
 	}()
 
@@ -269,6 +307,9 @@ func (s *swapState) reclaimMonero(skA *mcrypto.PrivateSpendKey) (mcrypto.Address
 		return "", err
 	}
 
+	s.bob.walletMu.Lock()
+	defer s.bob.walletMu.Unlock()
+
 	// TODO: check balance
 	return monero.CreateMoneroWallet("bob-swap-wallet", s.bob.env, s.bob.client, kpAB)
 }
@@ -459,6 +500,9 @@ func (s *swapState) lockFunds(amount common.MoneroAmount) (mcrypto.Address, erro
 	kp := mcrypto.SumSpendAndViewKeys(s.alicePublicKeys, s.pubkeys)
 	log.Infof("going to lock XMR funds, amount(piconero)=%d", amount)
 
+	s.bob.walletMu.Lock()
+	defer s.bob.walletMu.Unlock()
+
 	balance, err := s.bob.client.GetBalance(0)
 	if err != nil {
 		return "", err
@@ -475,6 +519,14 @@ func (s *swapState) lockFunds(amount common.MoneroAmount) (mcrypto.Address, erro
 
 	log.Infof("locked XMR, txHash=%s fee=%d", txResp.TxHash, txResp.Fee)
 
+	s.xmrLocked = true
+	if err = s.writeCheckpoint(); err != nil {
+		log.Warnf("failed to write checkpoint to infofile: %s", err)
+	}
+
+	// our XMR is now locked, so it no longer needs to be reserved
+	s.bob.releaseReservation(s)
+
 	bobAddr, err := s.bob.client.GetAddress(0)
 	if err != nil {
 		return "", err
@@ -513,9 +565,16 @@ func (s *swapState) claimFunds() (ethcommon.Hash, error) {
 
 	log.Infof("balance before claim: %v ETH", common.EtherAmount(*balance).AsEther())
 
+	swap, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
 	// call swap.Swap.Claim() w/ b.privkeys.sk, revealing Bob's secret spend key
 	sc := s.getSecret()
+	s.bob.txMu.Lock()
 	tx, err := s.contract.Claim(s.txOpts, s.contractSwapID, sc)
+	s.bob.txMu.Unlock()
 	if err != nil {
 		return ethcommon.Hash{}, err
 	}
@@ -532,5 +591,16 @@ func (s *swapState) claimFunds() (ethcommon.Hash, error) {
 	}
 
 	log.Infof("balance after claim: %v ETH", common.EtherAmount(*balance).AsEther())
+
+	// if the swap is executed on behalf of a customer, forward the claimed ether to them.
+	// the claim itself succeeded, so a failure here doesn't fail the swap; the funds remain
+	// in our account and must be forwarded manually.
+	if payout := s.info.Payout(); payout != nil && payout.Address != "" {
+		if _, err = s.forwardPayout(swap.Value); err != nil {
+			log.Errorf("failed to forward %v ETH to payout address %s (label=%q): %s",
+				common.EtherAmount(*swap.Value).AsEther(), payout.Address, payout.Label, err)
+		}
+	}
+
 	return tx.Hash(), nil
 }
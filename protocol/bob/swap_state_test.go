@@ -35,7 +35,7 @@ type mockNet struct {
 	msg net.Message
 }
 
-func (n *mockNet) SendSwapMessage(msg net.Message) error {
+func (n *mockNet) SendSwapMessage(msg net.Message, _ uint64) error {
 	n.msg = msg
 	return nil
 }
@@ -80,7 +80,7 @@ func newTestBob(t *testing.T) *Instance {
 
 func newTestInstance(t *testing.T) (*Instance, *swapState) {
 	bob := newTestBob(t)
-	swapState, err := newSwapState(bob, &types.Offer{}, nil, infofile, common.MoneroAmount(33), desiredAmout)
+	swapState, err := newSwapState(bob, &types.Offer{}, nil, infofile, common.MoneroAmount(33), desiredAmout, nil, "")
 	require.NoError(t, err)
 	return bob, swapState
 }
@@ -154,6 +154,35 @@ func TestSwapState_ClaimFunds(t *testing.T) {
 	require.True(t, swapState.info.Status().IsOngoing())
 }
 
+func TestSwapState_ClaimFunds_Payout(t *testing.T) {
+	bob, swapState := newTestInstance(t)
+	err := swapState.generateAndSetKeys()
+	require.NoError(t, err)
+
+	payoutAddr := ethcommon.HexToAddress("0x000000000000000000000000000000000000dead")
+	swapState.info.SetPayout(&types.Payout{
+		Label:   "customer",
+		Address: payoutAddr.String(),
+	})
+
+	balanceBefore, err := bob.ethClient.BalanceAt(context.Background(), payoutAddr, nil)
+	require.NoError(t, err)
+
+	claimKey := swapState.secp256k1Pub.Keccak256()
+	swapState.contractAddr, _, swapState.contract = newSwap(t, bob, swapState, claimKey,
+		[32]byte{}, big.NewInt(33), defaultTimeoutDuration)
+
+	_, err = swapState.contract.SetReady(swapState.txOpts, defaultContractSwapID)
+	require.NoError(t, err)
+
+	_, err = swapState.claimFunds()
+	require.NoError(t, err)
+
+	balanceAfter, err := bob.ethClient.BalanceAt(context.Background(), payoutAddr, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(33), new(big.Int).Sub(balanceAfter, balanceBefore))
+}
+
 func TestSwapState_handleSendKeysMessage(t *testing.T) {
 	_, s := newTestInstance(t)
 
@@ -450,7 +479,7 @@ func TestSwapState_Exit_Refunded(t *testing.T) {
 		MaximumAmount: 0.2,
 		ExchangeRate:  0.1,
 	}
-	b.MakeOffer(s.offer)
+	b.MakeOffer(s.offer, nil, "")
 
 	s.info.SetStatus(types.CompletedRefund)
 	err := s.Exit()
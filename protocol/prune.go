@@ -0,0 +1,129 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/noot/atomic-swap/common/types"
+)
+
+const archiveDirName = "archive"
+
+// PruneConfig contains the parameters for pruning swap infofiles.
+type PruneConfig struct {
+	Basepath  string
+	Retention time.Duration // successful swaps which completed more recently than this are kept
+	Archive   bool          // if true, key material is stripped and the file is moved into the archive directory
+	DryRun    bool          // if true, the files which would be pruned are returned, but not touched
+	Exclude   []string      // infofiles which must never be pruned, eg. that of the ongoing swap
+}
+
+// PruneInfoFiles removes (or archives) the infofiles in the basepath of swaps which completed
+// successfully longer ago than the retention period. It returns the paths of the pruned files.
+//
+// Infofiles of swaps which were refunded, aborted, are still ongoing, or whose status is unknown
+// are never pruned, as the key material they contain may still be needed to recover funds.
+func PruneInfoFiles(cfg *PruneConfig) ([]string, error) {
+	entries, err := os.ReadDir(cfg.Basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[string]struct{}, len(cfg.Exclude))
+	for _, path := range cfg.Exclude {
+		exclude[filepath.Clean(path)] = struct{}{}
+	}
+
+	var pruned []string
+	for _, entry := range entries {
+		if entry.IsDir() || !isInfoFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(cfg.Basepath, entry.Name())
+		if _, has := exclude[path]; has {
+			continue
+		}
+
+		// files which can't be parsed are kept, as we can't tell if they're still needed
+		contents, err := readInfoFile(path)
+		if err != nil {
+			continue
+		}
+
+		if !canPrune(contents, cfg.Retention) {
+			continue
+		}
+
+		if !cfg.DryRun {
+			if err = pruneInfoFile(cfg, path, contents); err != nil {
+				return pruned, fmt.Errorf("failed to prune %s: %w", path, err)
+			}
+		}
+
+		pruned = append(pruned, path)
+	}
+
+	return pruned, nil
+}
+
+func isInfoFile(name string) bool {
+	return (strings.HasPrefix(name, "info-") || strings.HasPrefix(name, "recovery-")) &&
+		strings.HasSuffix(name, ".txt")
+}
+
+func readInfoFile(path string) (*infoFileContents, error) {
+	bz, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var contents *infoFileContents
+	if err = json.Unmarshal(bz, &contents); err != nil {
+		return nil, err
+	}
+
+	if contents == nil {
+		return &infoFileContents{}, nil
+	}
+
+	return contents, nil
+}
+
+func canPrune(contents *infoFileContents, retention time.Duration) bool {
+	if contents.Status != types.CompletedSuccess.String() || contents.CompletedAt == nil {
+		return false
+	}
+
+	return time.Since(*contents.CompletedAt) >= retention
+}
+
+func pruneInfoFile(cfg *PruneConfig, path string, contents *infoFileContents) error {
+	if !cfg.Archive {
+		return os.Remove(path)
+	}
+
+	// the swap is complete, so the key material is no longer needed
+	contents.PrivateKeyInfo = nil
+	contents.SharedSwapPrivateKey = nil
+
+	bz, err := json.MarshalIndent(contents, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(cfg.Basepath, archiveDirName)
+	if err = makeDir(dir); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, filepath.Base(path)), bz, 0600); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,111 @@
+package protocol
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestInfoFile(t *testing.T, path string, status types.Status, completedAt time.Time) {
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	err = WriteKeysToFile(path, kp, common.Development)
+	require.NoError(t, err)
+
+	if status == types.UnknownStatus {
+		return
+	}
+
+	err = WriteSwapStatusToFile(path, status)
+	require.NoError(t, err)
+
+	contents, err := readInfoFile(path)
+	require.NoError(t, err)
+	require.Equal(t, status.String(), contents.Status)
+
+	if contents.CompletedAt != nil {
+		// backdate the completion time
+		file, contents, err := setupFile(path) //nolint:govet
+		require.NoError(t, err)
+		contents.CompletedAt = &completedAt
+		bz, err := json.MarshalIndent(contents, "", "\t")
+		require.NoError(t, err)
+		_, err = file.Write(bz)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+}
+
+func TestPruneInfoFiles(t *testing.T) {
+	basepath := t.TempDir()
+	old := time.Now().Add(-time.Hour * 24 * 60)
+
+	oldSuccess := filepath.Join(basepath, "info-old-success.txt")
+	newSuccess := filepath.Join(basepath, "info-new-success.txt")
+	oldRefund := filepath.Join(basepath, "info-old-refund.txt")
+	ongoing := filepath.Join(basepath, "info-ongoing.txt")
+	unknown := filepath.Join(basepath, "recovery-unknown.txt")
+	excluded := filepath.Join(basepath, "info-excluded.txt")
+	other := filepath.Join(basepath, "contractaddress")
+
+	writeTestInfoFile(t, oldSuccess, types.CompletedSuccess, old)
+	writeTestInfoFile(t, newSuccess, types.CompletedSuccess, time.Now())
+	writeTestInfoFile(t, oldRefund, types.CompletedRefund, old)
+	writeTestInfoFile(t, ongoing, types.XMRLocked, old)
+	writeTestInfoFile(t, unknown, types.UnknownStatus, old)
+	writeTestInfoFile(t, excluded, types.CompletedSuccess, old)
+	err := WriteContractAddressToFile(other, "0xabcd")
+	require.NoError(t, err)
+
+	cfg := &PruneConfig{
+		Basepath:  basepath,
+		Retention: time.Hour * 24 * 30,
+		DryRun:    true,
+		Exclude:   []string{excluded},
+	}
+
+	pruned, err := PruneInfoFiles(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{oldSuccess}, pruned)
+	require.FileExists(t, oldSuccess)
+
+	cfg.DryRun = false
+	pruned, err = PruneInfoFiles(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{oldSuccess}, pruned)
+	require.NoFileExists(t, oldSuccess)
+
+	for _, path := range []string{newSuccess, oldRefund, ongoing, unknown, excluded, other} {
+		require.FileExists(t, path)
+	}
+}
+
+func TestPruneInfoFiles_Archive(t *testing.T) {
+	basepath := t.TempDir()
+	path := filepath.Join(basepath, "info-success.txt")
+	writeTestInfoFile(t, path, types.CompletedSuccess, time.Now().Add(-time.Hour))
+	err := WriteSwapIDToFile(path, 7)
+	require.NoError(t, err)
+
+	pruned, err := PruneInfoFiles(&PruneConfig{
+		Basepath: basepath,
+		Archive:  true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{path}, pruned)
+	require.NoFileExists(t, path)
+
+	archived, err := readInfoFile(filepath.Join(basepath, archiveDirName, "info-success.txt"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), archived.SwapID)
+	require.Equal(t, types.CompletedSuccess.String(), archived.Status)
+	require.Nil(t, archived.PrivateKeyInfo)
+	require.Nil(t, archived.SharedSwapPrivateKey)
+}
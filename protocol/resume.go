@@ -0,0 +1,84 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+)
+
+// ResumableSwap is a swap which was ongoing when the daemon last exited, and which
+// can be resumed from its infofile.
+type ResumableSwap struct {
+	SwapCheckpoint
+	InfoFile        string
+	Status          types.Status
+	ContractAddress string
+	Secret          *mcrypto.PrivateSpendKey // nil if our keys were never generated
+	Payout          *types.Payout
+	CallbackURL     string
+}
+
+// GetResumableSwaps returns the swaps in the basepath where we provided the given coin, and which
+// were still ongoing when the daemon last exited. Infofiles written before checkpoints were added
+// can't be resumed, and are skipped.
+func GetResumableSwaps(basepath string, provides types.ProvidesCoin) ([]*ResumableSwap, error) {
+	entries, err := os.ReadDir(basepath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var swaps []*ResumableSwap
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "info-") || !isInfoFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(basepath, entry.Name())
+		contents, err := readInfoFile(path)
+		if err != nil || contents.Checkpoint == nil || contents.Checkpoint.Provides != provides {
+			continue
+		}
+
+		status := types.NewStatus(contents.Status)
+		if !status.IsOngoing() {
+			continue
+		}
+
+		rs := &ResumableSwap{
+			SwapCheckpoint:  *contents.Checkpoint,
+			InfoFile:        path,
+			Status:          status,
+			ContractAddress: contents.ContractAddress,
+			Payout:          contents.Payout,
+			CallbackURL:     contents.CallbackURL,
+		}
+
+		if contents.PrivateKeyInfo != nil {
+			rs.Secret, err = privateSpendKeyFromHex(contents.PrivateKeyInfo.PrivateSpendKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode private spend key in %s: %w", path, err)
+			}
+		}
+
+		swaps = append(swaps, rs)
+	}
+
+	return swaps, nil
+}
+
+func privateSpendKeyFromHex(skHex string) (*mcrypto.PrivateSpendKey, error) {
+	sk, err := hex.DecodeString(skHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcrypto.NewPrivateSpendKey(sk)
+}
@@ -0,0 +1,63 @@
+package protocol
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResumableSwaps(t *testing.T) {
+	basepath := t.TempDir()
+
+	writeSwap := func(name string, provides types.ProvidesCoin, status types.Status) *mcrypto.PrivateKeyPair {
+		path := filepath.Join(basepath, name)
+		kp, err := mcrypto.GenerateKeys()
+		require.NoError(t, err)
+		require.NoError(t, WriteKeysToFile(path, kp, common.Development))
+		require.NoError(t, WriteContractAddressToFile(path, "0xabcd"))
+		require.NoError(t, WriteSwapStatusToFile(path, status))
+		require.NoError(t, WriteCheckpointToFile(path, &SwapCheckpoint{
+			Provides:       provides,
+			ProvidedAmount: 1,
+			ReceivedAmount: 2,
+			ExchangeRate:   2,
+			ContractSwapID: big.NewInt(7),
+			XMRLocked:      true,
+		}))
+		return kp
+	}
+
+	kp := writeSwap("info-ongoing-xmr.txt", types.ProvidesXMR, types.XMRLocked)
+	writeSwap("info-ongoing-eth.txt", types.ProvidesETH, types.ETHLocked)
+	writeSwap("info-complete-xmr.txt", types.ProvidesXMR, types.CompletedSuccess)
+	writeSwap("recovery-xmr.txt", types.ProvidesXMR, types.XMRLocked)
+
+	// swaps without a checkpoint can't be resumed
+	writeTestInfoFile(t, filepath.Join(basepath, "info-old.txt"), types.XMRLocked, time.Time{})
+
+	swaps, err := GetResumableSwaps(basepath, types.ProvidesXMR)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(swaps))
+
+	rs := swaps[0]
+	require.Equal(t, filepath.Join(basepath, "info-ongoing-xmr.txt"), rs.InfoFile)
+	require.Equal(t, types.XMRLocked, rs.Status)
+	require.Equal(t, "0xabcd", rs.ContractAddress)
+	require.Equal(t, big.NewInt(7), rs.ContractSwapID)
+	require.Equal(t, 2.0, rs.ReceivedAmount)
+	require.True(t, rs.XMRLocked)
+	require.NotNil(t, rs.Secret)
+	require.Equal(t, kp.SpendKey().Hex(), rs.Secret.Hex())
+
+	swaps, err = GetResumableSwaps(basepath, types.ProvidesETH)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(swaps))
+	require.Equal(t, filepath.Join(basepath, "info-ongoing-eth.txt"), swaps[0].InfoFile)
+}
@@ -0,0 +1,89 @@
+package swap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/noot/atomic-swap/common/types"
+
+	logging "github.com/ipfs/go-log"
+)
+
+const callbackAttempts = 5
+
+var (
+	log = logging.Logger("swap")
+
+	callbackClient     = &http.Client{Timeout: time.Second * 30}
+	callbackRetryDelay = time.Second * 10
+)
+
+// Result is the payload POSTed to a swap's callback URL once the swap completes.
+type Result struct {
+	ID             uint64             `json:"id"`
+	Provided       types.ProvidesCoin `json:"provided"`
+	ProvidedAmount float64            `json:"providedAmount"`
+	ReceivedAmount float64            `json:"receivedAmount"`
+	ExchangeRate   types.ExchangeRate `json:"exchangeRate"`
+	Status         string             `json:"status"`
+	Label          string             `json:"label,omitempty"`
+	PayoutAddress  string             `json:"payoutAddress,omitempty"`
+}
+
+func newResult(info *Info) *Result {
+	res := &Result{
+		ID:             info.ID(),
+		Provided:       info.Provides(),
+		ProvidedAmount: info.ProvidedAmount(),
+		ReceivedAmount: info.ReceivedAmount(),
+		ExchangeRate:   info.ExchangeRate(),
+		Status:         info.Status().String(),
+	}
+
+	if payout := info.Payout(); payout != nil {
+		res.Label = payout.Label
+		res.PayoutAddress = payout.Address
+	}
+
+	return res
+}
+
+// notifyCallback POSTs the result of the completed swap to its callback URL, retrying if the
+// request fails or the endpoint doesn't respond with a 2xx status.
+func notifyCallback(info *Info) {
+	bz, err := json.Marshal(newResult(info))
+	if err != nil {
+		log.Errorf("failed to encode result of swap %d: %s", info.ID(), err)
+		return
+	}
+
+	for i := 0; i < callbackAttempts; i++ {
+		if i > 0 {
+			time.Sleep(callbackRetryDelay)
+		}
+
+		if err = postCallback(info.CallbackURL(), bz); err == nil {
+			log.Debugf("sent result of swap %d to callback URL %s", info.ID(), info.CallbackURL())
+			return
+		}
+
+		log.Warnf("failed to send result of swap %d to callback URL %s: %s", info.ID(), info.CallbackURL(), err)
+	}
+}
+
+func postCallback(url string, body []byte) error {
+	resp, err := callbackClient.Post(url, "application/json", bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %s", resp.Status)
+	}
+
+	return nil
+}
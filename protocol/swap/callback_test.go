@@ -0,0 +1,66 @@
+package swap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_CompleteOngoingSwap_Callback(t *testing.T) {
+	callbackRetryDelay = time.Millisecond
+
+	var attempts int32
+	resCh := make(chan *Result, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// fail the first request, to check that it's retried
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var res *Result
+		if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resCh <- res
+	}))
+	defer srv.Close()
+
+	m := NewManager()
+	info := &Info{
+		id:             1,
+		provides:       types.ProvidesETH,
+		providedAmount: 1,
+		receivedAmount: 10,
+		exchangeRate:   10,
+		status:         types.ExpectingKeys,
+		payout:         &types.Payout{Label: "customer"},
+		callbackURL:    srv.URL,
+	}
+	require.NoError(t, m.AddSwap(info))
+
+	info.SetStatus(types.CompletedSuccess)
+	m.CompleteOngoingSwap(info.ID())
+
+	select {
+	case res := <-resCh:
+		require.Equal(t, info.ID(), res.ID)
+		require.Equal(t, types.ProvidesETH, res.Provided)
+		require.Equal(t, float64(10), res.ReceivedAmount)
+		require.Equal(t, types.CompletedSuccess.String(), res.Status)
+		require.Equal(t, "customer", res.Label)
+	case <-time.After(time.Second * 5):
+		t.Fatal("callback wasn't called")
+	}
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
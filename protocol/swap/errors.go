@@ -5,5 +5,5 @@ import (
 )
 
 var (
-	errHaveOngoingSwap = errors.New("already have ongoing swap")
+	errHaveOngoingSwap = errors.New("already have ongoing swap with given ID")
 )
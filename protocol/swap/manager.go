@@ -2,6 +2,7 @@ package swap
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/noot/atomic-swap/common/types"
 )
@@ -21,6 +22,8 @@ type Info struct {
 	exchangeRate   types.ExchangeRate
 	status         Status
 	statusCh       <-chan types.Status
+	payout         *types.Payout
+	callbackURL    string
 }
 
 // ID returns the swap ID.
@@ -83,7 +86,7 @@ func (i *Info) SetStatus(s Status) {
 func NewInfo(provides types.ProvidesCoin, providedAmount, receivedAmount float64,
 	exchangeRate types.ExchangeRate, status Status, statusCh <-chan types.Status) *Info {
 	info := &Info{
-		id:             nextID,
+		id:             atomic.AddUint64(&nextID, 1) - 1,
 		provides:       provides,
 		providedAmount: providedAmount,
 		receivedAmount: receivedAmount,
@@ -91,14 +94,50 @@ func NewInfo(provides types.ProvidesCoin, providedAmount, receivedAmount float64
 		status:         status,
 		statusCh:       statusCh,
 	}
-	nextID++
 	return info
 }
 
+// Payout returns the swap's payout, if the swap is executed on behalf of a customer.
+func (i *Info) Payout() *types.Payout {
+	if i == nil {
+		return nil
+	}
+
+	return i.payout
+}
+
+// SetPayout sets the swap's payout. It must be called before the swap is added to the *Manager.
+func (i *Info) SetPayout(p *types.Payout) {
+	if i == nil {
+		return
+	}
+
+	i.payout = p
+}
+
+// CallbackURL returns the URL which the swap's result is sent to when it completes, if any.
+func (i *Info) CallbackURL() string {
+	if i == nil {
+		return ""
+	}
+
+	return i.callbackURL
+}
+
+// SetCallbackURL sets the URL which the swap's result is sent to when it completes.
+// It must be called before the swap is added to the *Manager.
+func (i *Info) SetCallbackURL(url string) {
+	if i == nil {
+		return
+	}
+
+	i.callbackURL = url
+}
+
 // Manager tracks current and past swaps.
 type Manager struct {
 	sync.RWMutex
-	ongoing     *Info
+	ongoing     map[uint64]*Info
 	past        map[uint64]*Info
 	offersTaken map[string]uint64 // map of offerID -> swapID
 }
@@ -106,6 +145,7 @@ type Manager struct {
 // NewManager ...
 func NewManager() *Manager {
 	return &Manager{
+		ongoing:     make(map[uint64]*Info),
 		past:        make(map[uint64]*Info),
 		offersTaken: make(map[string]uint64),
 	}
@@ -118,11 +158,11 @@ func (m *Manager) AddSwap(info *Info) error {
 
 	switch info.status.IsOngoing() {
 	case true:
-		if m.ongoing != nil {
+		if _, has := m.ongoing[info.id]; has {
 			return errHaveOngoingSwap
 		}
 
-		m.ongoing = info
+		m.ongoing[info.id] = info
 	default:
 		m.past[info.id] = info
 	}
@@ -150,19 +190,38 @@ func (m *Manager) GetPastSwap(id uint64) *Info {
 	return m.past[id]
 }
 
-// GetOngoingSwap returns the ongoing swap's *Info, if there is one.
-func (m *Manager) GetOngoingSwap() *Info {
-	return m.ongoing
+// GetOngoingSwap returns the *Info of the ongoing swap with the given ID, if there is one.
+func (m *Manager) GetOngoingSwap(id uint64) *Info {
+	m.RLock()
+	defer m.RUnlock()
+	return m.ongoing[id]
+}
+
+// GetOngoingSwaps returns the *Info of all ongoing swaps.
+func (m *Manager) GetOngoingSwaps() []*Info {
+	m.RLock()
+	defer m.RUnlock()
+	swaps := make([]*Info, 0, len(m.ongoing))
+	for _, info := range m.ongoing {
+		swaps = append(swaps, info)
+	}
+	return swaps
 }
 
-// CompleteOngoingSwap marks the current ongoing swap as completed.
-func (m *Manager) CompleteOngoingSwap() {
+// CompleteOngoingSwap marks the ongoing swap with the given ID as completed.
+// If the swap has a callback URL, its result is sent to it.
+func (m *Manager) CompleteOngoingSwap(id uint64) {
 	m.Lock()
 	defer m.Unlock()
-	if m.ongoing == nil {
+	info, has := m.ongoing[id]
+	if !has {
 		return
 	}
 
-	m.past[m.ongoing.id] = m.ongoing
-	m.ongoing = nil
+	m.past[id] = info
+	delete(m.ongoing, id)
+
+	if info.callbackURL != "" {
+		go notifyCallback(info)
+	}
 }
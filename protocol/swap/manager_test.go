@@ -16,15 +16,22 @@ func TestManager_AddSwap_Ongoing(t *testing.T) {
 	require.NoError(t, err)
 	err = m.AddSwap(info)
 	require.Equal(t, errHaveOngoingSwap, err)
-	require.Equal(t, info, m.GetOngoingSwap())
-	require.NotNil(t, m.ongoing)
+	require.Equal(t, info, m.GetOngoingSwap(info.ID()))
+	require.Equal(t, 1, len(m.ongoing))
 
-	m.CompleteOngoingSwap()
-	require.Nil(t, m.ongoing)
+	// multiple swaps may be ongoing at once
+	info2 := NewInfo(types.ProvidesXMR, 1, 1, 0.1, types.ExpectingKeys, nil)
+	err = m.AddSwap(info2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(m.GetOngoingSwaps()))
+
+	m.CompleteOngoingSwap(info.ID())
+	require.Nil(t, m.GetOngoingSwap(info.ID()))
+	require.Equal(t, info2, m.GetOngoingSwap(info2.ID()))
 	require.Equal(t, []uint64{0}, m.GetPastIDs())
-	require.Equal(t, uint64(1), nextID)
+	require.Equal(t, uint64(2), nextID)
 
-	m.CompleteOngoingSwap()
+	m.CompleteOngoingSwap(info.ID())
 }
 
 func TestManager_AddSwap_Past(t *testing.T) {
@@ -3,10 +3,13 @@ package protocol
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 )
 
@@ -15,6 +18,23 @@ type infoFileContents struct {
 	SwapID               uint64
 	PrivateKeyInfo       *mcrypto.PrivateKeyInfo
 	SharedSwapPrivateKey *mcrypto.PrivateKeyInfo
+	Status               string          `json:",omitempty"`
+	CompletedAt          *time.Time      `json:",omitempty"`
+	Payout               *types.Payout   `json:",omitempty"`
+	CallbackURL          string          `json:",omitempty"`
+	Checkpoint           *SwapCheckpoint `json:",omitempty"`
+}
+
+// SwapCheckpoint contains the state of an ongoing swap which is needed to resume it
+// if the daemon exits before the swap completes.
+type SwapCheckpoint struct {
+	Provides       types.ProvidesCoin
+	ProvidedAmount float64
+	ReceivedAmount float64
+	ExchangeRate   types.ExchangeRate
+	NewSwapTxHash  string   `json:",omitempty"` // transaction which locked the ether, set by the ETH provider
+	ContractSwapID *big.Int `json:",omitempty"` // set once the ether is locked
+	XMRLocked      bool     `json:",omitempty"`
 }
 
 // WriteContractAddressToFile writes the contract address to the given file
@@ -53,6 +73,83 @@ func WriteSwapIDToFile(infofile string, id uint64) error {
 	return err
 }
 
+// WritePayoutToFile writes the swap's payout to the given file
+func WritePayoutToFile(infofile string, payout *types.Payout) error {
+	file, contents, err := setupFile(infofile)
+	if err != nil {
+		return err
+	}
+
+	contents.Payout = payout
+
+	bz, err := json.MarshalIndent(contents, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bz)
+	return err
+}
+
+// WriteCallbackURLToFile writes the swap's callback URL to the given file
+func WriteCallbackURLToFile(infofile, url string) error {
+	file, contents, err := setupFile(infofile)
+	if err != nil {
+		return err
+	}
+
+	contents.CallbackURL = url
+
+	bz, err := json.MarshalIndent(contents, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bz)
+	return err
+}
+
+// WriteCheckpointToFile writes the swap's checkpoint to the given file
+func WriteCheckpointToFile(infofile string, checkpoint *SwapCheckpoint) error {
+	file, contents, err := setupFile(infofile)
+	if err != nil {
+		return err
+	}
+
+	contents.Checkpoint = checkpoint
+
+	bz, err := json.MarshalIndent(contents, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bz)
+	return err
+}
+
+// WriteSwapStatusToFile writes the swap's status to the given file. If the swap is no longer
+// ongoing, the time of completion is also written.
+func WriteSwapStatusToFile(infofile string, status types.Status) error {
+	file, contents, err := setupFile(infofile)
+	if err != nil {
+		return err
+	}
+
+	contents.Status = status.String()
+	if !status.IsOngoing() {
+		now := time.Now()
+		contents.CompletedAt = &now
+	}
+
+	bz, err := json.MarshalIndent(contents, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(bz)
+	return err
+}
+
 // WriteKeysToFile writes the given private key pair to the given file
 func WriteKeysToFile(infofile string, keys *mcrypto.PrivateKeyPair, env common.Environment) error {
 	file, contents, err := setupFile(infofile)
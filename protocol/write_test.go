@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,21 @@ func TestWriteKeysToFile(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWritePayoutToFile(t *testing.T) {
+	infofile := t.TempDir() + "/info-0.txt"
+	payout := &types.Payout{
+		Label:   "customer",
+		Address: "0xabcd",
+	}
+
+	err := WritePayoutToFile(infofile, payout)
+	require.NoError(t, err)
+
+	contents, err := readInfoFile(infofile)
+	require.NoError(t, err)
+	require.Equal(t, payout, contents.Payout)
+}
+
 func TestWriteContractAddrssToFile(t *testing.T) {
 	addr := "0xabcd"
 	err := WriteContractAddressToFile(os.TempDir()+"/test.keys", addr)
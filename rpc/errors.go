@@ -8,11 +8,13 @@ var (
 	// net_ errors
 	errNoOfferWithID       = errors.New("peer does not have offer with given ID")
 	errFailedToGetSwapInfo = errors.New("failed to get swap info after initiating")
+	errInvalidCallbackURL  = errors.New("callback URL must be an absolute http or https URL")
 
 	// swap_ errors
-	errNoSwapWithID  = errors.New("unable to find swap with given ID")
-	errNoOngoingSwap = errors.New("no current ongoing swap")
-	errCannotRefund  = errors.New("cannot refund if not the ETH provider")
+	errNoSwapWithID         = errors.New("unable to find swap with given ID")
+	errNoOngoingSwap        = errors.New("no current ongoing swap")
+	errMultipleOngoingSwaps = errors.New("multiple ongoing swaps, must specify swap ID")
+	errCannotRefund         = errors.New("cannot refund if not the ETH provider")
 
 	// ws errors
 	errUnimplemented = errors.New("unimplemented")
@@ -3,6 +3,7 @@ package rpc
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/noot/atomic-swap/common"
@@ -22,7 +23,7 @@ type Net interface {
 	Discover(provides types.ProvidesCoin, searchTime time.Duration) ([]peer.AddrInfo, error)
 	Query(who peer.AddrInfo) (*net.QueryResponse, error)
 	Initiate(who peer.AddrInfo, msg *net.SendKeysMessage, s common.SwapState) error
-	CloseProtocolStream()
+	CloseProtocolStream(id uint64)
 }
 
 // NetService is the RPC service prefixed by net_.
@@ -106,7 +107,7 @@ func (s *NetService) QueryPeer(_ *http.Request, req *rpctypes.QueryPeerRequest,
 // TakeOffer initiates a swap with the given peer by taking an offer they've made.
 func (s *NetService) TakeOffer(_ *http.Request, req *rpctypes.TakeOfferRequest,
 	resp *rpctypes.TakeOfferResponse) error {
-	id, _, infofile, err := s.takeOffer(req.Multiaddr, req.OfferID, req.ProvidesAmount)
+	id, _, infofile, err := s.takeOffer(req)
 	if err != nil {
 		return err
 	}
@@ -116,9 +117,12 @@ func (s *NetService) TakeOffer(_ *http.Request, req *rpctypes.TakeOfferRequest,
 	return nil
 }
 
-func (s *NetService) takeOffer(multiaddr, offerID string,
-	providesAmount float64) (uint64, <-chan types.Status, string, error) {
-	who, err := net.StringToAddrInfo(multiaddr)
+func (s *NetService) takeOffer(req *rpctypes.TakeOfferRequest) (uint64, <-chan types.Status, string, error) {
+	if err := validateCallbackURL(req.CallbackURL); err != nil {
+		return 0, nil, "", err
+	}
+
+	who, err := net.StringToAddrInfo(req.Multiaddr)
 	if err != nil {
 		return 0, nil, "", err
 	}
@@ -133,7 +137,7 @@ func (s *NetService) takeOffer(multiaddr, offerID string,
 		offer *types.Offer
 	)
 	for _, maybeOffer := range queryResp.Offers {
-		if maybeOffer.GetID().String() == offerID {
+		if maybeOffer.GetID().String() == req.OfferID {
 			found = true
 			offer = maybeOffer
 			break
@@ -144,7 +148,8 @@ func (s *NetService) takeOffer(multiaddr, offerID string,
 		return 0, nil, "", errNoOfferWithID
 	}
 
-	swapState, err := s.alice.InitiateProtocol(providesAmount, offer)
+	swapState, err := s.alice.InitiateProtocol(req.ProvidesAmount, offer, newPayout(req.Label, req.PayoutAddress),
+		req.CallbackURL)
 	if err != nil {
 		return 0, nil, "", err
 	}
@@ -154,15 +159,15 @@ func (s *NetService) takeOffer(multiaddr, offerID string,
 		return 0, nil, "", err
 	}
 
-	skm.OfferID = offerID
-	skm.ProvidedAmount = providesAmount
+	skm.OfferID = req.OfferID
+	skm.ProvidedAmount = req.ProvidesAmount
 
 	if err = s.net.Initiate(who, skm, swapState); err != nil {
 		_ = swapState.Exit()
 		return 0, nil, "", err
 	}
 
-	info := s.sm.GetOngoingSwap()
+	info := s.sm.GetOngoingSwap(swapState.ID())
 	if info == nil {
 		return 0, nil, "", errFailedToGetSwapInfo
 	}
@@ -181,7 +186,7 @@ type TakeOfferSyncResponse struct {
 // It synchronously waits until the swap is completed before returning its status.
 func (s *NetService) TakeOfferSync(_ *http.Request, req *rpctypes.TakeOfferRequest,
 	resp *TakeOfferSyncResponse) error {
-	id, _, infofile, err := s.takeOffer(req.Multiaddr, req.OfferID, req.ProvidesAmount)
+	id, _, infofile, err := s.takeOffer(req)
 	if err != nil {
 		return err
 	}
@@ -228,7 +233,11 @@ func (s *NetService) makeOffer(req *rpctypes.MakeOfferRequest) (string, *types.O
 		ExchangeRate:  req.ExchangeRate,
 	}
 
-	offerExtra, err := s.bob.MakeOffer(o)
+	if err := validateCallbackURL(req.CallbackURL); err != nil {
+		return "", nil, err
+	}
+
+	offerExtra, err := s.bob.MakeOffer(o, newPayout(req.Label, req.PayoutAddress), req.CallbackURL)
 	if err != nil {
 		return "", nil, err
 	}
@@ -236,6 +245,37 @@ func (s *NetService) makeOffer(req *rpctypes.MakeOfferRequest) (string, *types.O
 	return o.GetID().String(), offerExtra, nil
 }
 
+// newPayout returns the payout for a swap executed on behalf of a customer, or nil if
+// neither a label nor a payout address was given.
+func newPayout(label, address string) *types.Payout {
+	if label == "" && address == "" {
+		return nil
+	}
+
+	return &types.Payout{
+		Label:   label,
+		Address: address,
+	}
+}
+
+// validateCallbackURL checks that the given callback URL, if set, is an absolute HTTP(S) URL.
+func validateCallbackURL(callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidCallbackURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidCallbackURL
+	}
+
+	return nil
+}
+
 // SetGasPriceRequest ...
 type SetGasPriceRequest struct {
 	GasPrice uint64
@@ -55,6 +55,24 @@ func TestNet_TakeOffer(t *testing.T) {
 	require.Equal(t, testSwapID, resp.ID)
 }
 
+func TestNet_TakeOffer_InvalidCallbackURL(t *testing.T) {
+	ns := NewNetService(new(mockNet), new(mockAlice), nil, new(mockSwapManager))
+
+	offer := &types.Offer{}
+
+	req := &rpctypes.TakeOfferRequest{
+		Multiaddr:      "/ip4/127.0.0.1/tcp/9900/p2p/12D3KooWDqCzbjexHEa8Rut7bzxHFpRMZyDRW1L6TGkL1KY24JH5",
+		OfferID:        offer.GetID().String(),
+		ProvidesAmount: 1,
+		CallbackURL:    "localhost:8080/callback",
+	}
+
+	resp := new(rpctypes.TakeOfferResponse)
+
+	err := ns.TakeOffer(nil, req, resp)
+	require.ErrorIs(t, err, errInvalidCallbackURL)
+}
+
 func TestNet_TakeOfferSync(t *testing.T) {
 	ns := NewNetService(new(mockNet), new(mockAlice), nil, new(mockSwapManager))
 
@@ -37,6 +37,8 @@ type Config struct {
 	Alice       Alice
 	Bob         Bob
 	SwapManager SwapManager
+	Basepath    string
+	Retention   time.Duration // retention period of infofiles of successfully completed swaps
 }
 
 // NewServer ...
@@ -53,7 +55,8 @@ func NewServer(cfg *Config) (*Server, error) {
 		return nil, err
 	}
 
-	if err := s.RegisterService(NewSwapService(cfg.SwapManager, cfg.Alice, cfg.Bob, cfg.Net), "swap"); err != nil {
+	ss := NewSwapService(cfg.SwapManager, cfg.Alice, cfg.Bob, cfg.Net, cfg.Basepath, cfg.Retention)
+	if err := s.RegisterService(ss, "swap"); err != nil {
 		return nil, err
 	}
 
@@ -108,13 +111,14 @@ func (s *Server) Start() <-chan error {
 type Protocol interface {
 	Provides() types.ProvidesCoin
 	SetGasPrice(gasPrice uint64)
-	GetOngoingSwapState() common.SwapState
+	GetOngoingSwapState(id uint64) common.SwapState
 }
 
 // Alice ...
 type Alice interface {
 	Protocol
-	InitiateProtocol(providesAmount float64, offer *types.Offer) (common.SwapState, error)
+	InitiateProtocol(providesAmount float64, offer *types.Offer, payout *types.Payout,
+		callbackURL string) (common.SwapState, error)
 	Refund() (ethcommon.Hash, error)
 	SetSwapTimeout(timeout time.Duration)
 }
@@ -122,7 +126,7 @@ type Alice interface {
 // Bob ...
 type Bob interface {
 	Protocol
-	MakeOffer(offer *types.Offer) (*types.OfferExtra, error)
+	MakeOffer(offer *types.Offer, payout *types.Payout, callbackURL string) (*types.OfferExtra, error)
 	SetMoneroWalletFile(file, password string) error
 	GetOffers() []*types.Offer
 	ClearOffers()
@@ -132,5 +136,6 @@ type Bob interface {
 type SwapManager interface {
 	GetPastIDs() []uint64
 	GetPastSwap(id uint64) *swap.Info
-	GetOngoingSwap() *swap.Info
+	GetOngoingSwap(id uint64) *swap.Info
+	GetOngoingSwaps() []*swap.Info
 }
@@ -3,26 +3,34 @@ package rpc
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
+	pcommon "github.com/noot/atomic-swap/protocol"
+	"github.com/noot/atomic-swap/protocol/swap"
 )
 
 // SwapService handles information about ongoing or past swaps.
 type SwapService struct {
-	sm    SwapManager
-	alice Alice
-	bob   Bob
-	net   Net
+	sm        SwapManager
+	alice     Alice
+	bob       Bob
+	net       Net
+	basepath  string
+	retention time.Duration
 }
 
 // NewSwapService ...
-func NewSwapService(sm SwapManager, alice Alice, bob Bob, net Net) *SwapService {
+func NewSwapService(sm SwapManager, alice Alice, bob Bob, net Net, basepath string,
+	retention time.Duration) *SwapService {
 	return &SwapService{
-		sm:    sm,
-		alice: alice,
-		bob:   bob,
-		net:   net,
+		sm:        sm,
+		alice:     alice,
+		bob:       bob,
+		net:       net,
+		basepath:  basepath,
+		retention: retention,
 	}
 }
 
@@ -49,6 +57,8 @@ type GetPastResponse struct {
 	ReceivedAmount float64            `json:"receivedAmount"`
 	ExchangeRate   types.ExchangeRate `json:"exchangeRate"`
 	Status         string             `json:"status"`
+	Label          string             `json:"label,omitempty"`
+	PayoutAddress  string             `json:"payoutAddress,omitempty"`
 }
 
 // GetPast returns information about a past swap, given its ID.
@@ -63,9 +73,53 @@ func (s *SwapService) GetPast(_ *http.Request, req *GetPastRequest, resp *GetPas
 	resp.ReceivedAmount = info.ReceivedAmount()
 	resp.ExchangeRate = info.ExchangeRate()
 	resp.Status = info.Status().String()
+	if payout := info.Payout(); payout != nil {
+		resp.Label = payout.Label
+		resp.PayoutAddress = payout.Address
+	}
 	return nil
 }
 
+// getOngoingSwap returns the ongoing swap with the given ID. If no ID is given, it returns
+// the only ongoing swap, and errors if there are several.
+func (s *SwapService) getOngoingSwap(id *uint64) (*swap.Info, error) {
+	if id != nil {
+		info := s.sm.GetOngoingSwap(*id)
+		if info == nil {
+			return nil, errNoOngoingSwap
+		}
+
+		return info, nil
+	}
+
+	swaps := s.sm.GetOngoingSwaps()
+	switch len(swaps) {
+	case 0:
+		return nil, errNoOngoingSwap
+	case 1:
+		return swaps[0], nil
+	default:
+		return nil, errMultipleOngoingSwaps
+	}
+}
+
+func (s *SwapService) getOngoingSwapState(info *swap.Info) common.SwapState {
+	switch info.Provides() {
+	case types.ProvidesETH:
+		return s.alice.GetOngoingSwapState(info.ID())
+	case types.ProvidesXMR:
+		return s.bob.GetOngoingSwapState(info.ID())
+	default:
+		return nil
+	}
+}
+
+// GetOngoingRequest ...
+type GetOngoingRequest struct {
+	// ID of the ongoing swap; it may be omitted if there's only one ongoing swap.
+	ID *uint64 `json:"id"`
+}
+
 // GetOngoingResponse ...
 type GetOngoingResponse struct {
 	ID             uint64             `json:"id"`
@@ -74,13 +128,15 @@ type GetOngoingResponse struct {
 	ReceivedAmount float64            `json:"receivedAmount"`
 	ExchangeRate   types.ExchangeRate `json:"exchangeRate"`
 	Status         string             `json:"status"`
+	Label          string             `json:"label,omitempty"`
+	PayoutAddress  string             `json:"payoutAddress,omitempty"`
 }
 
-// GetOngoing returns information about the ongoing swap, if there is one.
-func (s *SwapService) GetOngoing(_ *http.Request, _ *interface{}, resp *GetOngoingResponse) error {
-	info := s.sm.GetOngoingSwap()
-	if info == nil {
-		return errNoOngoingSwap
+// GetOngoing returns information about the ongoing swap with the given ID, if there is one.
+func (s *SwapService) GetOngoing(_ *http.Request, req *GetOngoingRequest, resp *GetOngoingResponse) error {
+	info, err := s.getOngoingSwap(req.ID)
+	if err != nil {
+		return err
 	}
 
 	resp.ID = info.ID()
@@ -89,6 +145,10 @@ func (s *SwapService) GetOngoing(_ *http.Request, _ *interface{}, resp *GetOngoi
 	resp.ReceivedAmount = info.ReceivedAmount()
 	resp.ExchangeRate = info.ExchangeRate()
 	resp.Status = info.Status().String()
+	if payout := info.Payout(); payout != nil {
+		resp.Label = payout.Label
+		resp.PayoutAddress = payout.Address
+	}
 	return nil
 }
 
@@ -100,12 +160,15 @@ type RefundResponse struct {
 // Refund refunds the ongoing swap if we are the ETH provider.
 // TODO: remove in favour of swap_cancel?
 func (s *SwapService) Refund(_ *http.Request, _ *interface{}, resp *RefundResponse) error {
-	info := s.sm.GetOngoingSwap()
-	if info == nil {
-		return errNoOngoingSwap
+	var info *swap.Info
+	for _, ongoing := range s.sm.GetOngoingSwaps() {
+		if ongoing.Provides() == types.ProvidesETH {
+			info = ongoing
+			break
+		}
 	}
 
-	if info.Provides() != types.ProvidesETH {
+	if info == nil {
 		return errCannotRefund
 	}
 
@@ -118,17 +181,23 @@ func (s *SwapService) Refund(_ *http.Request, _ *interface{}, resp *RefundRespon
 	return nil
 }
 
+// GetStageRequest ...
+type GetStageRequest struct {
+	// ID of the ongoing swap; it may be omitted if there's only one ongoing swap.
+	ID *uint64 `json:"id"`
+}
+
 // GetStageResponse ...
 type GetStageResponse struct {
 	Stage string `json:"stage"`
 	Info  string `json:"info"`
 }
 
-// GetStage returns the stage of the ongoing swap, if there is one.
-func (s *SwapService) GetStage(_ *http.Request, _ *interface{}, resp *GetStageResponse) error {
-	info := s.sm.GetOngoingSwap()
-	if info == nil {
-		return errNoOngoingSwap
+// GetStage returns the stage of the ongoing swap with the given ID, if there is one.
+func (s *SwapService) GetStage(_ *http.Request, req *GetStageRequest, resp *GetStageResponse) error {
+	info, err := s.getOngoingSwap(req.ID)
+	if err != nil {
+		return err
 	}
 
 	resp.Stage = info.Status().String()
@@ -147,32 +216,80 @@ func (s *SwapService) GetOffers(_ *http.Request, _ *interface{}, resp *GetOffers
 	return nil
 }
 
+// CancelRequest ...
+type CancelRequest struct {
+	// ID of the ongoing swap; it may be omitted if there's only one ongoing swap.
+	ID *uint64 `json:"id"`
+}
+
 // CancelResponse ...
 type CancelResponse struct {
 	Status types.Status `json:"status"`
 }
 
-// Cancel attempts to cancel the currently ongoing swap, if there is one.
-func (s *SwapService) Cancel(_ *http.Request, _ *interface{}, resp *CancelResponse) error {
-	info := s.sm.GetOngoingSwap()
-	if info == nil {
-		return errNoOngoingSwap
+// Cancel attempts to cancel the ongoing swap with the given ID, if there is one.
+func (s *SwapService) Cancel(_ *http.Request, req *CancelRequest, resp *CancelResponse) error {
+	info, err := s.getOngoingSwap(req.ID)
+	if err != nil {
+		return err
 	}
 
-	var ss common.SwapState
-	switch info.Provides() {
-	case types.ProvidesETH:
-		ss = s.alice.GetOngoingSwapState()
-	case types.ProvidesXMR:
-		ss = s.bob.GetOngoingSwapState()
+	ss := s.getOngoingSwapState(info)
+	if ss == nil {
+		return errNoOngoingSwap
 	}
 
-	if err := ss.Exit(); err != nil {
+	if err = ss.Exit(); err != nil {
 		return err
 	}
-	s.net.CloseProtocolStream()
+	s.net.CloseProtocolStream(info.ID())
 
 	info = s.sm.GetPastSwap(info.ID())
 	resp.Status = info.Status()
 	return nil
 }
+
+// PruneRequest ...
+type PruneRequest struct {
+	// RetentionDays overrides the daemon's configured retention period, if set.
+	RetentionDays *uint64 `json:"retentionDays"`
+	Archive       bool    `json:"archive"`
+	DryRun        bool    `json:"dryRun"`
+}
+
+// PruneResponse ...
+type PruneResponse struct {
+	Pruned []string `json:"pruned"`
+}
+
+// Prune removes the infofiles of swaps which completed successfully longer ago than the retention
+// period. If archive is set, the infofiles are moved to the archive directory with their key
+// material removed instead. Infofiles of ongoing swaps, or of any swap which did not complete
+// successfully, are never pruned.
+func (s *SwapService) Prune(_ *http.Request, req *PruneRequest, resp *PruneResponse) error {
+	retention := s.retention
+	if req.RetentionDays != nil {
+		retention = time.Duration(*req.RetentionDays) * 24 * time.Hour
+	}
+
+	var exclude []string
+	for _, info := range s.sm.GetOngoingSwaps() {
+		if ss := s.getOngoingSwapState(info); ss != nil {
+			exclude = append(exclude, ss.InfoFile())
+		}
+	}
+
+	pruned, err := pcommon.PruneInfoFiles(&pcommon.PruneConfig{
+		Basepath:  s.basepath,
+		Retention: retention,
+		Archive:   req.Archive,
+		DryRun:    req.DryRun,
+		Exclude:   exclude,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune infofiles: %w", err)
+	}
+
+	resp.Pruned = pruned
+	return nil
+}
@@ -53,7 +53,7 @@ func (*mockNet) Query(who peer.AddrInfo) (*net.QueryResponse, error) {
 func (*mockNet) Initiate(who peer.AddrInfo, msg *net.SendKeysMessage, s common.SwapState) error {
 	return nil
 }
-func (*mockNet) CloseProtocolStream() {}
+func (*mockNet) CloseProtocolStream(_ uint64) {}
 
 type mockSwapManager struct{}
 
@@ -63,7 +63,7 @@ func (*mockSwapManager) GetPastIDs() []uint64 {
 func (*mockSwapManager) GetPastSwap(id uint64) *swap.Info {
 	return &swap.Info{}
 }
-func (*mockSwapManager) GetOngoingSwap() *swap.Info {
+func (*mockSwapManager) GetOngoingSwap(_ uint64) *swap.Info {
 	statusCh := make(chan types.Status, 1)
 	statusCh <- types.CompletedSuccess
 
@@ -76,6 +76,9 @@ func (*mockSwapManager) GetOngoingSwap() *swap.Info {
 		statusCh,
 	)
 }
+func (m *mockSwapManager) GetOngoingSwaps() []*swap.Info {
+	return []*swap.Info{m.GetOngoingSwap(0)}
+}
 
 type mockAlice struct{}
 
@@ -83,10 +86,11 @@ func (*mockAlice) Provides() types.ProvidesCoin {
 	return types.ProvidesETH
 }
 func (*mockAlice) SetGasPrice(gasPrice uint64) {}
-func (*mockAlice) GetOngoingSwapState() common.SwapState {
+func (*mockAlice) GetOngoingSwapState(_ uint64) common.SwapState {
 	return new(mockSwapState)
 }
-func (*mockAlice) InitiateProtocol(providesAmount float64, _ *types.Offer) (common.SwapState, error) {
+func (*mockAlice) InitiateProtocol(providesAmount float64, _ *types.Offer, _ *types.Payout,
+	_ string) (common.SwapState, error) {
 	return new(mockSwapState), nil
 }
 func (*mockAlice) Refund() (ethcommon.Hash, error) {
@@ -197,7 +201,7 @@ func TestSubscribeTakeOffer(t *testing.T) {
 
 	offerID := (&types.Offer{}).GetID()
 
-	id, ch, err := c.TakeOfferAndSubscribe(testMultiaddr, offerID.String(), 1)
+	id, ch, err := c.TakeOfferAndSubscribe(testMultiaddr, offerID.String(), 1, nil)
 	require.NoError(t, err)
 	require.Equal(t, id, testSwapID)
 	select {
@@ -8,13 +8,22 @@ import (
 	"github.com/noot/atomic-swap/rpc"
 )
 
-// Cancel calls swap_cancel.
-func (c *Client) Cancel() (types.Status, error) {
+// Cancel calls swap_cancel. The ID may be nil if there's only one ongoing swap.
+func (c *Client) Cancel(id *uint64) (types.Status, error) {
 	const (
 		method = "swap_cancel"
 	)
 
-	resp, err := rpctypes.PostRPC(c.endpoint, method, "{}")
+	req := &rpc.CancelRequest{
+		ID: id,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := rpctypes.PostRPC(c.endpoint, method, string(params))
 	if err != nil {
 		return 0, err
 	}
@@ -9,7 +9,7 @@ import (
 )
 
 // MakeOffer calls net_makeOffer.
-func (c *Client) MakeOffer(min, max, exchangeRate float64) (string, error) {
+func (c *Client) MakeOffer(min, max, exchangeRate float64, payout *types.Payout) (string, error) {
 	const (
 		method = "net_makeOffer"
 	)
@@ -20,6 +20,11 @@ func (c *Client) MakeOffer(min, max, exchangeRate float64) (string, error) {
 		ExchangeRate:  types.ExchangeRate(exchangeRate),
 	}
 
+	if payout != nil {
+		req.PayoutAddress = payout.Address
+		req.Label = payout.Label
+	}
+
 	params, err := json.Marshal(req)
 	if err != nil {
 		return "", err
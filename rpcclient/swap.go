@@ -31,13 +31,22 @@ func (c *Client) GetPastSwapIDs() ([]uint64, error) {
 	return res.IDs, nil
 }
 
-// GetOngoingSwap calls swap_getOngoing
-func (c *Client) GetOngoingSwap() (*rpc.GetOngoingResponse, error) {
+// GetOngoingSwap calls swap_getOngoing. The ID may be nil if there's only one ongoing swap.
+func (c *Client) GetOngoingSwap(id *uint64) (*rpc.GetOngoingResponse, error) {
 	const (
 		method = "swap_getOngoing"
 	)
 
-	resp, err := rpctypes.PostRPC(c.endpoint, method, "{}")
+	req := &rpc.GetOngoingRequest{
+		ID: id,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rpctypes.PostRPC(c.endpoint, method, string(params))
 	if err != nil {
 		return nil, err
 	}
@@ -109,13 +118,22 @@ func (c *Client) Refund() (*rpc.RefundResponse, error) {
 	return res, nil
 }
 
-// GetStage calls swap_getStage
-func (c *Client) GetStage() (*rpc.GetStageResponse, error) {
+// GetStage calls swap_getStage. The ID may be nil if there's only one ongoing swap.
+func (c *Client) GetStage(id *uint64) (*rpc.GetStageResponse, error) {
 	const (
 		method = "swap_getStage"
 	)
 
-	resp, err := rpctypes.PostRPC(c.endpoint, method, "{}")
+	req := &rpc.GetStageRequest{
+		ID: id,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rpctypes.PostRPC(c.endpoint, method, string(params))
 	if err != nil {
 		return nil, err
 	}
@@ -131,3 +149,37 @@ func (c *Client) GetStage() (*rpc.GetStageResponse, error) {
 
 	return res, nil
 }
+
+// Prune calls swap_prune
+func (c *Client) Prune(retentionDays *uint64, archive, dryRun bool) ([]string, error) {
+	const (
+		method = "swap_prune"
+	)
+
+	req := &rpc.PruneRequest{
+		RetentionDays: retentionDays,
+		Archive:       archive,
+		DryRun:        dryRun,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rpctypes.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	var res *rpc.PruneResponse
+	if err = json.Unmarshal(resp.Result, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Pruned, nil
+}
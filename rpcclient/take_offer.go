@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/noot/atomic-swap/common/rpctypes"
+	"github.com/noot/atomic-swap/common/types"
 )
 
 // TakeOffer calls net_takeOffer.
-func (c *Client) TakeOffer(maddr string, offerID string, providesAmount float64) (uint64, error) {
+func (c *Client) TakeOffer(maddr string, offerID string, providesAmount float64,
+	payout *types.Payout) (uint64, error) {
 	const (
 		method = "net_takeOffer"
 	)
@@ -19,6 +21,11 @@ func (c *Client) TakeOffer(maddr string, offerID string, providesAmount float64)
 		ProvidesAmount: providesAmount,
 	}
 
+	if payout != nil {
+		req.PayoutAddress = payout.Address
+		req.Label = payout.Label
+	}
+
 	params, err := json.Marshal(req)
 	if err != nil {
 		return 0, err
@@ -21,10 +21,10 @@ type WsClient interface {
 	Discover(provides types.ProvidesCoin, searchTime uint64) ([][]string, error)
 	Query(maddr string) (*rpctypes.QueryPeerResponse, error)
 	SubscribeSwapStatus(id uint64) (<-chan types.Status, error)
-	TakeOfferAndSubscribe(multiaddr, offerID string,
-		providesAmount float64) (id uint64, ch <-chan types.Status, err error)
-	MakeOfferAndSubscribe(min, max float64,
-		exchangeRate types.ExchangeRate) (string, <-chan *MakeOfferTakenResponse, <-chan types.Status, error)
+	TakeOfferAndSubscribe(multiaddr, offerID string, providesAmount float64,
+		payout *types.Payout) (id uint64, ch <-chan types.Status, err error)
+	MakeOfferAndSubscribe(min, max float64, exchangeRate types.ExchangeRate,
+		payout *types.Payout) (string, <-chan *MakeOfferTakenResponse, <-chan types.Status, error)
 }
 
 type wsClient struct {
@@ -227,14 +227,19 @@ func (c *wsClient) SubscribeSwapStatus(id uint64) (<-chan types.Status, error) {
 	return respCh, nil
 }
 
-func (c *wsClient) TakeOfferAndSubscribe(multiaddr, offerID string,
-	providesAmount float64) (id uint64, ch <-chan types.Status, err error) {
+func (c *wsClient) TakeOfferAndSubscribe(multiaddr, offerID string, providesAmount float64,
+	payout *types.Payout) (id uint64, ch <-chan types.Status, err error) {
 	params := &rpctypes.TakeOfferRequest{
 		Multiaddr:      multiaddr,
 		OfferID:        offerID,
 		ProvidesAmount: providesAmount,
 	}
 
+	if payout != nil {
+		params.PayoutAddress = payout.Address
+		params.Label = payout.Label
+	}
+
 	bz, err := json.Marshal(params)
 	if err != nil {
 		return 0, nil, err
@@ -320,14 +325,19 @@ type MakeOfferTakenResponse struct {
 	ID uint64 `json:"id"`
 }
 
-func (c *wsClient) MakeOfferAndSubscribe(min, max float64,
-	exchangeRate types.ExchangeRate) (string, <-chan *MakeOfferTakenResponse, <-chan types.Status, error) {
+func (c *wsClient) MakeOfferAndSubscribe(min, max float64, exchangeRate types.ExchangeRate,
+	payout *types.Payout) (string, <-chan *MakeOfferTakenResponse, <-chan types.Status, error) {
 	params := &rpctypes.MakeOfferRequest{
 		MinimumAmount: min,
 		MaximumAmount: max,
 		ExchangeRate:  exchangeRate,
 	}
 
+	if payout != nil {
+		params.PayoutAddress = payout.Address
+		params.Label = payout.Label
+	}
+
 	bz, err := json.Marshal(params)
 	if err != nil {
 		return "", nil, nil, err
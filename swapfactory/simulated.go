@@ -0,0 +1,29 @@
+package swapfactory
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const simulatedGasLimit = 8000000
+
+// SimulatedChainID is the chain ID used by go-ethereum's simulated backend.
+var SimulatedChainID = big.NewInt(1337)
+
+// NewSimulatedBackend returns an in-memory ethereum backend where the account of each of
+// the given keys is funded with the given balance. It allows the contract to be exercised
+// without an external ethereum node; transactions are only included once Commit() is called.
+func NewSimulatedBackend(balance *big.Int, keys ...*ecdsa.PrivateKey) *backends.SimulatedBackend {
+	alloc := make(core.GenesisAlloc)
+	for _, key := range keys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{
+			Balance: balance,
+		}
+	}
+
+	return backends.NewSimulatedBackend(alloc, simulatedGasLimit)
+}
@@ -5,10 +5,13 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"math/big"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -19,31 +22,164 @@ import (
 	"github.com/noot/atomic-swap/dleq"
 )
 
+const (
+	// the tests run against an in-memory simulated backend, unless this is set to ganacheBackend,
+	// in which case they run against ganache at common.DefaultEthEndpoint.
+	testBackendEnv = "SWAPFACTORY_BACKEND"
+	ganacheBackend = "ganache"
+)
+
 var defaultTimeoutDuration = big.NewInt(60) // 60 seconds
 
-func setupAliceAuth(t *testing.T) (*bind.TransactOpts, *ethclient.Client, *ecdsa.PrivateKey) {
+type testBackend interface {
+	bind.ContractBackend
+	TransactionReceipt(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error)
+	BalanceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// testEnv is the chain the tests are run against.
+type testEnv struct {
+	conn testBackend
+
+	// commit includes any pending transactions in a block; it's a no-op for ganache,
+	// which mines transactions instantly.
+	commit func()
+
+	// increaseTime moves the chain's clock forwards.
+	increaseTime func(t *testing.T, d time.Duration)
+}
+
+func newTestEnv(t *testing.T, keys ...*ecdsa.PrivateKey) *testEnv {
+	if os.Getenv(testBackendEnv) == ganacheBackend {
+		return newGanacheTestEnv(t)
+	}
+
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	sim := NewSimulatedBackend(balance, keys...)
+	t.Cleanup(func() {
+		_ = sim.Close()
+	})
+
+	return &testEnv{
+		conn:   sim,
+		commit: sim.Commit,
+		increaseTime: func(t *testing.T, d time.Duration) {
+			require.NoError(t, sim.AdjustTime(d))
+			sim.Commit()
+		},
+	}
+}
+
+func newGanacheTestEnv(t *testing.T) *testEnv {
 	conn, err := ethclient.Dial(common.DefaultEthEndpoint)
 	require.NoError(t, err)
-	pkA, err := crypto.HexToECDSA(common.DefaultPrivKeyAlice)
+
+	return &testEnv{
+		conn:   conn,
+		commit: func() {},
+		increaseTime: func(t *testing.T, d time.Duration) {
+			rpcClient, err := rpc.Dial(common.DefaultEthEndpoint)
+			require.NoError(t, err)
+
+			var result string
+			err = rpcClient.Call(&result, "evm_snapshot")
+			require.NoError(t, err)
+
+			err = rpcClient.Call(nil, "evm_increaseTime", int64(d.Seconds()))
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				var ok bool
+				err = rpcClient.Call(&ok, "evm_revert", result)
+				require.NoError(t, err)
+			})
+		},
+	}
+}
+
+func chainID() *big.Int {
+	if os.Getenv(testBackendEnv) == ganacheBackend {
+		return big.NewInt(common.GanacheChainID)
+	}
+
+	return SimulatedChainID
+}
+
+func newTransactor(t *testing.T, key string) (*bind.TransactOpts, *ecdsa.PrivateKey) {
+	pk, err := crypto.HexToECDSA(key)
 	require.NoError(t, err)
-	auth, err := bind.NewKeyedTransactorWithChainID(pkA, big.NewInt(common.GanacheChainID))
+	auth, err := bind.NewKeyedTransactorWithChainID(pk, chainID())
 	require.NoError(t, err)
-	return auth, conn, pkA
+	return auth, pk
 }
 
-func TestSwapFactory_NewSwap(t *testing.T) {
-	auth, conn, _ := setupAliceAuth(t)
-	address, tx, contract, err := DeploySwapFactory(auth, conn)
+func setupAliceAuth(t *testing.T) (*bind.TransactOpts, *testEnv, *ecdsa.PrivateKey) {
+	auth, pkA := newTransactor(t, common.DefaultPrivKeyAlice)
+	return auth, newTestEnv(t, pkA), pkA
+}
+
+// deploy deploys SwapFactory.sol and returns the contract.
+func (env *testEnv) deploy(t *testing.T, auth *bind.TransactOpts) (*SwapFactory, uint64) {
+	address, tx, contract, err := DeploySwapFactory(auth, env.conn)
 	require.NoError(t, err)
 	require.NotEqual(t, ethcommon.Address{}, address)
-	require.NotNil(t, tx)
-	require.NotNil(t, contract)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	env.commit()
+	return contract, tx.Gas()
+}
 
-	tx, err = contract.NewSwap(auth, [32]byte{}, [32]byte{},
-		ethcommon.Address{}, defaultTimeoutDuration)
+// newSwap calls new_swap on the contract and returns the ID of the new swap.
+func (env *testEnv) newSwap(t *testing.T, auth *bind.TransactOpts, contract *SwapFactory, claimKey,
+	refundKey [32]byte, claimer ethcommon.Address, value *big.Int) *big.Int {
+	auth.Value = value
+	defer func() {
+		auth.Value = nil
+	}()
+
+	tx, err := contract.NewSwap(auth, claimKey, refundKey, claimer, defaultTimeoutDuration)
 	require.NoError(t, err)
 	t.Logf("gas cost to call new_swap: %d", tx.Gas())
+	env.commit()
+
+	receipt, err := env.conn.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(receipt.Logs))
+	id, err := GetIDFromLog(receipt.Logs[0])
+	require.NoError(t, err)
+	return id
+}
+
+// requireReverted checks that the given transaction failed. Transactions which revert
+// during gas estimation are never sent; otherwise, the receipt's status is checked.
+func (env *testEnv) requireReverted(t *testing.T, tx *ethtypes.Transaction, err error) {
+	if err != nil {
+		return
+	}
+
+	env.commit()
+	receipt, err := env.conn.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.ReceiptStatusFailed, receipt.Status)
+}
+
+func generateSecret(t *testing.T) ([32]byte, [32]byte) {
+	dleq := &dleq.FarcasterDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	var s [32]byte
+	secret := proof.Secret()
+	copy(s[:], common.Reverse(secret[:]))
+	return s, res.Secp256k1PublicKey().Keccak256()
+}
+
+func TestSwapFactory_NewSwap(t *testing.T) {
+	auth, env, _ := setupAliceAuth(t)
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
+
+	env.newSwap(t, auth, contract, [32]byte{}, [32]byte{}, ethcommon.Address{}, nil)
 }
 
 func TestSwapFactory_Claim_vec(t *testing.T) {
@@ -63,35 +199,27 @@ func TestSwapFactory_Claim_vec(t *testing.T) {
 	cmt := pk.Keccak256()
 
 	// deploy swap contract with claim key hash
-	auth, conn, pkA := setupAliceAuth(t)
+	auth, env, pkA := setupAliceAuth(t)
 	pub := pkA.Public().(*ecdsa.PublicKey)
 	addr := crypto.PubkeyToAddress(*pub)
 	t.Logf("commitment: 0x%x", cmt)
 
-	_, tx, contract, err := DeploySwapFactory(auth, conn)
-	require.NoError(t, err)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
 
-	tx, err = contract.NewSwap(auth, cmt, [32]byte{}, addr,
-		defaultTimeoutDuration)
-	require.NoError(t, err)
-	t.Logf("gas cost to call new_swap: %d", tx.Gas())
-
-	receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
-	require.NoError(t, err)
-	require.Equal(t, 1, len(receipt.Logs))
-	id, err := GetIDFromLog(receipt.Logs[0])
-	require.NoError(t, err)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
 
 	// set contract to Ready
-	tx, err = contract.SetReady(auth, id)
+	tx, err := contract.SetReady(auth, id)
 	require.NoError(t, err)
 	t.Logf("gas cost to call set_ready: %d", tx.Gas())
+	env.commit()
 
 	// now let's try to claim
 	tx, err = contract.Claim(auth, id, s)
 	require.NoError(t, err)
 	t.Logf("gas cost to call claim: %d", tx.Gas())
+	env.commit()
 
 	callOpts := &bind.CallOpts{
 		From:    crypto.PubkeyToAddress(*pub),
@@ -105,47 +233,29 @@ func TestSwapFactory_Claim_vec(t *testing.T) {
 
 func TestSwap_Claim_random(t *testing.T) {
 	// generate claim secret and public key
-	dleq := &dleq.FarcasterDLEq{}
-	proof, err := dleq.Prove()
-	require.NoError(t, err)
-	res, err := dleq.Verify(proof)
-	require.NoError(t, err)
-
-	// hash public key
-	cmt := res.Secp256k1PublicKey().Keccak256()
+	s, cmt := generateSecret(t)
 
 	// deploy swap contract with claim key hash
-	auth, conn, pkA := setupAliceAuth(t)
+	auth, env, pkA := setupAliceAuth(t)
 	pub := pkA.Public().(*ecdsa.PublicKey)
 	addr := crypto.PubkeyToAddress(*pub)
 
-	_, tx, contract, err := DeploySwapFactory(auth, conn)
-	require.NoError(t, err)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
 
-	tx, err = contract.NewSwap(auth, cmt, [32]byte{}, addr,
-		defaultTimeoutDuration)
-	require.NoError(t, err)
-	t.Logf("gas cost to call new_swap: %d", tx.Gas())
-
-	receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
-	require.NoError(t, err)
-	require.Equal(t, 1, len(receipt.Logs))
-	id, err := GetIDFromLog(receipt.Logs[0])
-	require.NoError(t, err)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
 
 	// set contract to Ready
-	tx, err = contract.SetReady(auth, id)
+	tx, err := contract.SetReady(auth, id)
 	require.NoError(t, err)
 	t.Logf("gas cost to call SetReady: %d", tx.Gas())
+	env.commit()
 
 	// now let's try to claim
-	var s [32]byte
-	secret := proof.Secret()
-	copy(s[:], common.Reverse(secret[:]))
 	tx, err = contract.Claim(auth, id, s)
 	require.NoError(t, err)
 	t.Logf("gas cost to call Claim: %d", tx.Gas())
+	env.commit()
 
 	callOpts := &bind.CallOpts{
 		From:    crypto.PubkeyToAddress(*pub),
@@ -157,44 +267,138 @@ func TestSwap_Claim_random(t *testing.T) {
 	require.True(t, info.Completed)
 }
 
-func TestSwap_Refund_beforeT0(t *testing.T) {
-	// generate refund secret and public key
-	dleq := &dleq.FarcasterDLEq{}
-	proof, err := dleq.Prove()
+func TestSwap_Claim_transfersValue(t *testing.T) {
+	s, cmt := generateSecret(t)
+
+	authA, pkA := newTransactor(t, common.DefaultPrivKeyAlice)
+	authB, pkB := newTransactor(t, common.DefaultPrivKeyBob)
+	env := newTestEnv(t, pkA, pkB)
+	addrB := crypto.PubkeyToAddress(pkB.PublicKey)
+
+	contract, _ := env.deploy(t, authA)
+	value := big.NewInt(1e18)
+	id := env.newSwap(t, authA, contract, cmt, [32]byte{}, addrB, value)
+
+	tx, err := contract.SetReady(authA, id)
 	require.NoError(t, err)
-	res, err := dleq.Verify(proof)
+	env.commit()
+
+	// only the claimer can claim
+	tx, err = contract.Claim(authA, id, s)
+	env.requireReverted(t, tx, err)
+
+	before, err := env.conn.BalanceAt(context.Background(), addrB, nil)
 	require.NoError(t, err)
 
-	// hash public key
-	cmt := res.Secp256k1PublicKey().Keccak256()
+	tx, err = contract.Claim(authB, id, s)
+	require.NoError(t, err)
+	env.commit()
 
-	// deploy swap contract with refund key hash
-	auth, conn, pkA := setupAliceAuth(t)
-	pub := pkA.Public().(*ecdsa.PublicKey)
-	addr := crypto.PubkeyToAddress(*pub)
+	receipt, err := env.conn.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
 
-	_, tx, contract, err := DeploySwapFactory(auth, conn)
+	after, err := env.conn.BalanceAt(context.Background(), addrB, nil)
 	require.NoError(t, err)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
 
-	tx, err = contract.NewSwap(auth, [32]byte{}, cmt, addr,
-		defaultTimeoutDuration)
+	fee := new(big.Int).Mul(tx.GasPrice(), big.NewInt(int64(receipt.GasUsed)))
+	expected := new(big.Int).Sub(new(big.Int).Add(before, value), fee)
+	require.True(t, after.Cmp(before) > 0)
+	require.True(t, after.Cmp(expected) >= 0)
+}
+
+func TestSwap_Claim_invalidSecret(t *testing.T) {
+	_, cmt := generateSecret(t)
+	s, _ := generateSecret(t)
+
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
+
+	tx, err := contract.SetReady(auth, id)
 	require.NoError(t, err)
-	t.Logf("gas cost to call new_swap: %d", tx.Gas())
+	env.commit()
+
+	tx, err = contract.Claim(auth, id, s)
+	env.requireReverted(t, tx, err)
+}
+
+func TestSwap_Claim_beforeReady(t *testing.T) {
+	s, cmt := generateSecret(t)
+
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
+
+	// too early to claim, as set_ready wasn't called and t0 hasn't passed
+	tx, err := contract.Claim(auth, id, s)
+	env.requireReverted(t, tx, err)
+}
+
+func TestSwap_Claim_afterT0(t *testing.T) {
+	s, cmt := generateSecret(t)
 
-	receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
+
+	// fast forward past t0; claiming is allowed even though set_ready wasn't called
+	env.increaseTime(t, time.Duration(defaultTimeoutDuration.Int64())*time.Second)
+
+	_, err := contract.Claim(auth, id, s)
 	require.NoError(t, err)
-	require.Equal(t, 1, len(receipt.Logs))
-	id, err := GetIDFromLog(receipt.Logs[0])
+	env.commit()
+
+	info, err := contract.Swaps(&bind.CallOpts{}, id)
 	require.NoError(t, err)
+	require.True(t, info.Completed)
+}
+
+func TestSwap_Claim_afterT1(t *testing.T) {
+	s, cmt := generateSecret(t)
+
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, cmt, [32]byte{}, addr, nil)
+
+	tx, err := contract.SetReady(auth, id)
+	require.NoError(t, err)
+	env.commit()
+
+	// fast forward past t1
+	env.increaseTime(t, time.Duration(defaultTimeoutDuration.Int64()*2+60)*time.Second)
+
+	tx, err = contract.Claim(auth, id, s)
+	env.requireReverted(t, tx, err)
+}
+
+func TestSwap_Refund_beforeT0(t *testing.T) {
+	// generate refund secret and public key
+	s, cmt := generateSecret(t)
+
+	// deploy swap contract with refund key hash
+	auth, env, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
+
+	id := env.newSwap(t, auth, contract, [32]byte{}, cmt, addr, nil)
 
 	// now let's try to refund
-	var s [32]byte
-	secret := proof.Secret()
-	copy(s[:], common.Reverse(secret[:]))
-	tx, err = contract.Refund(auth, id, s)
+	tx, err := contract.Refund(auth, id, s)
 	require.NoError(t, err)
 	t.Logf("gas cost to call Refund: %d", tx.Gas())
+	env.commit()
 
 	callOpts := &bind.CallOpts{
 		From:    crypto.PubkeyToAddress(*pub),
@@ -206,61 +410,62 @@ func TestSwap_Refund_beforeT0(t *testing.T) {
 	require.True(t, info.Completed)
 }
 
-func TestSwap_Refund_afterT1(t *testing.T) {
-	// generate refund secret and public key
-	dleq := &dleq.FarcasterDLEq{}
-	proof, err := dleq.Prove()
-	require.NoError(t, err)
-	res, err := dleq.Verify(proof)
-	require.NoError(t, err)
+func TestSwap_Refund_afterReady(t *testing.T) {
+	s, cmt := generateSecret(t)
 
-	// hash public key
-	cmt := res.Secp256k1PublicKey().Keccak256()
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
 
-	// deploy swap contract with refund key hash
-	auth, conn, pkA := setupAliceAuth(t)
-	pub := pkA.Public().(*ecdsa.PublicKey)
-	addr := crypto.PubkeyToAddress(*pub)
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, [32]byte{}, cmt, addr, nil)
 
-	_, tx, contract, err := DeploySwapFactory(auth, conn)
+	tx, err := contract.SetReady(auth, id)
 	require.NoError(t, err)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	env.commit()
 
-	tx, err = contract.NewSwap(auth, [32]byte{}, cmt, addr,
-		defaultTimeoutDuration)
-	require.NoError(t, err)
-	t.Logf("gas cost to call new_swap: %d", tx.Gas())
+	// it's the counterparty's turn until t1
+	tx, err = contract.Refund(auth, id, s)
+	env.requireReverted(t, tx, err)
+}
 
-	receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
-	require.NoError(t, err)
-	require.Equal(t, 1, len(receipt.Logs))
-	id, err := GetIDFromLog(receipt.Logs[0])
-	require.NoError(t, err)
+func TestSwap_Refund_betweenT0AndT1(t *testing.T) {
+	s, cmt := generateSecret(t)
 
-	// fast forward past t1
-	rpcClient, err := rpc.Dial(common.DefaultEthEndpoint)
-	require.NoError(t, err)
+	auth, env, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
 
-	var result string
-	err = rpcClient.Call(&result, "evm_snapshot")
-	require.NoError(t, err)
+	contract, _ := env.deploy(t, auth)
+	id := env.newSwap(t, auth, contract, [32]byte{}, cmt, addr, nil)
 
-	err = rpcClient.Call(nil, "evm_increaseTime", defaultTimeoutDuration.Int64()*2+60)
-	require.NoError(t, err)
+	// fast forward past t0, but not t1
+	env.increaseTime(t, time.Duration(defaultTimeoutDuration.Int64())*time.Second)
 
-	defer func() {
-		var ok bool
-		err = rpcClient.Call(&ok, "evm_revert", result)
-		require.NoError(t, err)
-	}()
+	tx, err := contract.Refund(auth, id, s)
+	env.requireReverted(t, tx, err)
+}
+
+func TestSwap_Refund_afterT1(t *testing.T) {
+	// generate refund secret and public key
+	s, cmt := generateSecret(t)
+
+	// deploy swap contract with refund key hash
+	auth, env, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
+
+	id := env.newSwap(t, auth, contract, [32]byte{}, cmt, addr, nil)
+
+	// fast forward past t1
+	env.increaseTime(t, time.Duration(defaultTimeoutDuration.Int64()*2+60)*time.Second)
 
 	// now let's try to refund
-	var s [32]byte
-	secret := proof.Secret()
-	copy(s[:], common.Reverse(secret[:]))
-	tx, err = contract.Refund(auth, id, s)
+	tx, err := contract.Refund(auth, id, s)
 	require.NoError(t, err)
 	t.Logf("gas cost to call Refund: %d", tx.Gas())
+	env.commit()
 
 	callOpts := &bind.CallOpts{
 		From:    crypto.PubkeyToAddress(*pub),
@@ -270,71 +475,79 @@ func TestSwap_Refund_afterT1(t *testing.T) {
 	info, err := contract.Swaps(callOpts, id)
 	require.NoError(t, err)
 	require.True(t, info.Completed)
+
+	// the swap can't be refunded or claimed again
+	tx, err = contract.Refund(auth, id, s)
+	env.requireReverted(t, tx, err)
 }
 
 func TestSwap_MultipleSwaps(t *testing.T) {
 	// test case where contract has multiple swaps happening at once
-	auth, conn, pkA := setupAliceAuth(t)
+	auth, env, pkA := setupAliceAuth(t)
 	pub := pkA.Public().(*ecdsa.PublicKey)
 	addr := crypto.PubkeyToAddress(*pub)
 
-	_, tx, contract, err := DeploySwapFactory(auth, conn)
-	require.NoError(t, err)
-	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	contract, gas := env.deploy(t, auth)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", gas)
 
 	numSwaps := 16
 	type swapCase struct {
 		id     *big.Int
 		secret [32]byte
+		tx     *ethtypes.Transaction
 	}
 
-	// setup all swap instances in contract
+	// setup all swap instances in contract; the transactions are all included in the same
+	// block, so that none of the swaps time out before they're claimed
 	swapCases := []*swapCase{}
 	for i := 0; i < numSwaps; i++ {
 		sc := &swapCase{}
 
 		// generate claim secret and public key
-		dleq := &dleq.FarcasterDLEq{}
-		proof, err := dleq.Prove() //nolint:govet
-		require.NoError(t, err)
-		res, err := dleq.Verify(proof)
-		require.NoError(t, err)
-
-		// hash public key
-		cmt := res.Secp256k1PublicKey().Keccak256()
-		secret := proof.Secret()
-		copy(sc.secret[:], common.Reverse(secret[:]))
+		var cmt [32]byte
+		sc.secret, cmt = generateSecret(t)
 
-		tx, err = contract.NewSwap(auth, cmt, [32]byte{}, addr,
+		tx, err := contract.NewSwap(auth, cmt, [32]byte{}, addr,
 			defaultTimeoutDuration)
 		require.NoError(t, err)
 		t.Logf("gas cost to call new_swap: %d", tx.Gas())
+		sc.tx = tx
 
-		receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
+		swapCases = append(swapCases, sc)
+	}
+
+	env.commit()
+
+	for _, sc := range swapCases {
+		receipt, err := env.conn.TransactionReceipt(context.Background(), sc.tx.Hash())
 		require.NoError(t, err)
 		require.Equal(t, 1, len(receipt.Logs))
 		sc.id, err = GetIDFromLog(receipt.Logs[0])
 		require.NoError(t, err)
 
-		swapCases = append(swapCases, sc)
-	}
-
-	for _, sc := range swapCases {
 		// set contract to Ready
-		tx, err = contract.SetReady(auth, sc.id)
+		tx, err := contract.SetReady(auth, sc.id)
 		require.NoError(t, err)
 		t.Logf("gas cost to call SetReady: %d", tx.Gas())
+	}
 
+	env.commit()
+
+	for _, sc := range swapCases {
 		// now let's try to claim
-		tx, err = contract.Claim(auth, sc.id, sc.secret)
+		tx, err := contract.Claim(auth, sc.id, sc.secret)
 		require.NoError(t, err)
 		t.Logf("gas cost to call Claim: %d", tx.Gas())
+	}
 
-		callOpts := &bind.CallOpts{
-			From:    crypto.PubkeyToAddress(*pub),
-			Context: context.Background(),
-		}
+	env.commit()
 
+	callOpts := &bind.CallOpts{
+		From:    crypto.PubkeyToAddress(*pub),
+		Context: context.Background(),
+	}
+
+	for _, sc := range swapCases {
 		info, err := contract.Swaps(callOpts, sc.id)
 		require.NoError(t, err)
 		require.True(t, info.Completed)
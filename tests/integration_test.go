@@ -95,7 +95,7 @@ func generateBlocksAsync() {
 
 func TestAlice_Discover(t *testing.T) {
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
-	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate)
+	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate, nil)
 	require.NoError(t, err)
 
 	c := rpcclient.NewClient(defaultAliceDaemonEndpoint)
@@ -114,7 +114,7 @@ func TestBob_Discover(t *testing.T) {
 
 func TestAlice_Query(t *testing.T) {
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
-	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate)
+	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate, nil)
 	require.NoError(t, err)
 
 	c := rpcclient.NewClient(defaultAliceDaemonEndpoint)
@@ -142,7 +142,7 @@ func TestSuccess(t *testing.T) {
 	require.NoError(t, err)
 
 	offerID, takenCh, statusCh, err := bwsc.MakeOfferAndSubscribe(0.1, bobProvideAmount,
-		types.ExchangeRate(exchangeRate))
+		types.ExchangeRate(exchangeRate), nil)
 	require.NoError(t, err)
 
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
@@ -191,7 +191,7 @@ func TestSuccess(t *testing.T) {
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -243,7 +243,7 @@ func TestRefund_AliceCancels(t *testing.T) {
 	require.NoError(t, err)
 
 	offerID, takenCh, statusCh, err := bwsc.MakeOfferAndSubscribe(0.1, bobProvideAmount,
-		types.ExchangeRate(exchangeRate))
+		types.ExchangeRate(exchangeRate), nil)
 	require.NoError(t, err)
 
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
@@ -294,7 +294,7 @@ func TestRefund_AliceCancels(t *testing.T) {
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -306,7 +306,7 @@ func TestRefund_AliceCancels(t *testing.T) {
 			}
 
 			fmt.Println("> Alice cancelled swap!")
-			exitStatus, err := c.Cancel() //nolint:govet
+			exitStatus, err := c.Cancel(nil) //nolint:govet
 			if err != nil {
 				t.Log("Alice got error", err)
 				errCh <- err
@@ -367,7 +367,7 @@ func testRefundBobCancels(t *testing.T, swapTimeout uint64, expectedExitStatus t
 	require.NoError(t, err)
 
 	offerID, takenCh, statusCh, err := bwsc.MakeOfferAndSubscribe(0.1, bobProvideAmount,
-		types.ExchangeRate(exchangeRate))
+		types.ExchangeRate(exchangeRate), nil)
 	require.NoError(t, err)
 
 	offersBefore, err := bcli.GetOffers()
@@ -398,7 +398,7 @@ func testRefundBobCancels(t *testing.T, swapTimeout uint64, expectedExitStatus t
 			}
 
 			fmt.Println("> Bob cancelled swap!")
-			exitStatus, err := bcli.Cancel() //nolint:govet
+			exitStatus, err := bcli.Cancel(nil) //nolint:govet
 			if err != nil {
 				errCh <- err
 				return
@@ -426,7 +426,7 @@ func testRefundBobCancels(t *testing.T, swapTimeout uint64, expectedExitStatus t
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -484,7 +484,7 @@ func TestAbort_AliceCancels(t *testing.T) {
 	require.NoError(t, err)
 
 	offerID, takenCh, statusCh, err := bwsc.MakeOfferAndSubscribe(0.1, bobProvideAmount,
-		types.ExchangeRate(exchangeRate))
+		types.ExchangeRate(exchangeRate), nil)
 	require.NoError(t, err)
 
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
@@ -532,7 +532,7 @@ func TestAbort_AliceCancels(t *testing.T) {
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -544,7 +544,7 @@ func TestAbort_AliceCancels(t *testing.T) {
 			}
 
 			fmt.Println("> Alice cancelled swap!")
-			exitStatus, err := c.Cancel() //nolint:govet
+			exitStatus, err := c.Cancel(nil) //nolint:govet
 			if err != nil {
 				errCh <- err
 				return
@@ -592,7 +592,7 @@ func TestAbort_BobCancels(t *testing.T) {
 	require.NoError(t, err)
 
 	offerID, takenCh, statusCh, err := bwsc.MakeOfferAndSubscribe(0.1, bobProvideAmount,
-		types.ExchangeRate(exchangeRate))
+		types.ExchangeRate(exchangeRate), nil)
 	require.NoError(t, err)
 
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
@@ -624,7 +624,7 @@ func TestAbort_BobCancels(t *testing.T) {
 			}
 
 			fmt.Println("> Bob cancelled swap!")
-			exitStatus, err := bcli.Cancel() //nolint:govet
+			exitStatus, err := bcli.Cancel(nil) //nolint:govet
 			if err != nil {
 				errCh <- err
 				return
@@ -649,7 +649,7 @@ func TestAbort_BobCancels(t *testing.T) {
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+	id, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -696,7 +696,7 @@ func TestError_ShouldOnlyTakeOfferOnce(t *testing.T) {
 	defer cancel()
 
 	bc := rpcclient.NewClient(defaultBobDaemonEndpoint)
-	offerID, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate)
+	offerID, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate, nil)
 	require.NoError(t, err)
 
 	ac := rpcclient.NewClient(defaultAliceDaemonEndpoint)
@@ -712,7 +712,7 @@ func TestError_ShouldOnlyTakeOfferOnce(t *testing.T) {
 		wsc, err := wsclient.NewWsClient(ctx, defaultAliceDaemonWSEndpoint)
 		require.NoError(t, err)
 
-		_, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+		_, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 		if err != nil {
 			errCh <- err
 			return
@@ -738,7 +738,7 @@ func TestError_ShouldOnlyTakeOfferOnce(t *testing.T) {
 		wsc, err := wsclient.NewWsClient(ctx, defaultCharlieDaemonWSEndpoint)
 		require.NoError(t, err)
 
-		_, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05)
+		_, takerStatusCh, err := wsc.TakeOfferAndSubscribe(providers[0][0], offerID, 0.05, nil)
 		if err != nil {
 			errCh <- err
 			return